@@ -0,0 +1,184 @@
+package pocket
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotArchived is returned by Delete with WithRequireArchived when the
+// item is still in the unread list.
+var ErrNotArchived = errors.New("item is not archived")
+
+// ActionOption tweaks an action method such as Archive or TagRename.
+type ActionOption func(*actionOptions)
+
+type actionOptions struct {
+	time            time.Time
+	requireArchived bool
+	dryRun          bool
+	predicate       Predicate
+}
+
+// WithDryRun validates and, for the bulk helpers, retrieves as usual but
+// skips the request that would modify the account. Send then returns a
+// response with DryRun set, and the helpers count what they would have
+// changed.
+func WithDryRun() ActionOption {
+	return func(o *actionOptions) {
+		o.dryRun = true
+	}
+}
+
+// WithActionTime records the action as having happened at t instead of
+// when it is sent, so imports keep their original dates.
+func WithActionTime(t time.Time) ActionOption {
+	return func(o *actionOptions) {
+		o.time = t
+	}
+}
+
+// WithRequireArchived makes Delete look the item up first and refuse with
+// ErrNotArchived unless it is archived, so only items already dealt with can
+// be removed for good. The lookup pages through the account.
+func WithRequireArchived() ActionOption {
+	return func(o *actionOptions) {
+		o.requireArchived = true
+	}
+}
+
+// WithPredicate makes the bulk helpers act only on the items pred keeps,
+// for conditions the retrieve filters cannot express, such as OlderThan.
+// Unless the filter sets a Detail, items are then retrieved with complete
+// detail so that predicates like HasTag see the tags.
+func WithPredicate(pred Predicate) ActionOption {
+	return func(o *actionOptions) {
+		o.predicate = pred
+	}
+}
+
+func applyActionOptions(opts []ActionOption) actionOptions {
+	var o actionOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// Archive moves an item to the archive.
+func (c *Client) Archive(ctx context.Context, accessToken string, itemID ItemID, opts ...ActionOption) error {
+	return c.itemAction(ctx, accessToken, ActionArchive, itemID, opts)
+}
+
+// Readd moves an archived item back to the unread list. Pocket also bumps it
+// to the top of the list, as if it had just been saved.
+func (c *Client) Readd(ctx context.Context, accessToken string, itemID ItemID, opts ...ActionOption) error {
+	return c.itemAction(ctx, accessToken, ActionReadd, itemID, opts)
+}
+
+// Favorite marks an item as a favorite. Favoriting an item twice succeeds.
+// An item ID Pocket does not know fails with ErrItemNotFound.
+func (c *Client) Favorite(ctx context.Context, accessToken string, itemID ItemID, opts ...ActionOption) error {
+	return notFoundOnFailure(c.itemAction(ctx, accessToken, ActionFavorite, itemID, opts))
+}
+
+// Unfavorite clears the favorite mark, failing with ErrItemNotFound like
+// Favorite.
+func (c *Client) Unfavorite(ctx context.Context, accessToken string, itemID ItemID, opts ...ActionOption) error {
+	return notFoundOnFailure(c.itemAction(ctx, accessToken, ActionUnfavorite, itemID, opts))
+}
+
+// notFoundOnFailure attributes a rejected favorite action to an unknown
+// item, which is what a rejected favorite means in practice.
+func notFoundOnFailure(err error) error {
+	var aerr *ActionError
+	var berr *BatchError
+	if errors.As(err, &aerr) && errors.As(aerr.Err, &berr) {
+		aerr.Err = ErrItemNotFound
+	}
+
+	return err
+}
+
+// Delete permanently removes an item. This cannot be undone; see
+// WithRequireArchived for a guard.
+func (c *Client) Delete(ctx context.Context, accessToken string, itemID ItemID, opts ...ActionOption) error {
+	if err := validateItemID(itemID); err != nil {
+		return err
+	}
+
+	if applyActionOptions(opts).requireArchived {
+		item, err := c.getItemByID(ctx, accessToken, itemID)
+		if err != nil {
+			return err
+		}
+
+		if !item.IsArchived() {
+			return ErrNotArchived
+		}
+	}
+
+	return c.itemAction(ctx, accessToken, ActionDelete, itemID, opts)
+}
+
+// TagsAdd adds tags to an item, keeping the ones it already has.
+func (c *Client) TagsAdd(ctx context.Context, accessToken string, itemID ItemID, tags []string, opts ...ActionOption) error {
+	return c.tagsAction(ctx, accessToken, ActionTagsAdd, itemID, tags, opts)
+}
+
+// TagsRemove removes tags from an item. Tags the item does not carry are
+// ignored by Pocket.
+func (c *Client) TagsRemove(ctx context.Context, accessToken string, itemID ItemID, tags []string, opts ...ActionOption) error {
+	return c.tagsAction(ctx, accessToken, ActionTagsRemove, itemID, tags, opts)
+}
+
+// TagsReplace overwrites the item's whole tag set with tags. An empty tags is
+// rejected rather than read as "remove everything"; use TagsClear for that.
+func (c *Client) TagsReplace(ctx context.Context, accessToken string, itemID ItemID, tags []string, opts ...ActionOption) error {
+	return c.tagsAction(ctx, accessToken, ActionTagsReplace, itemID, tags, opts)
+}
+
+// TagsClear removes every tag from an item.
+func (c *Client) TagsClear(ctx context.Context, accessToken string, itemID ItemID, opts ...ActionOption) error {
+	return c.itemAction(ctx, accessToken, ActionTagsClear, itemID, opts)
+}
+
+func (c *Client) tagsAction(ctx context.Context, accessToken string, action ActionType, itemID ItemID, tags []string, opts []ActionOption) error {
+	a, err := newTagsAction(action, itemID, tags)
+	if err != nil {
+		return err
+	}
+
+	return c.sendOne(ctx, accessToken, a, opts)
+}
+
+func (c *Client) itemAction(ctx context.Context, accessToken string, action ActionType, itemID ItemID, opts []ActionOption) error {
+	a, err := newItemAction(action, itemID)
+	if err != nil {
+		return err
+	}
+
+	return c.sendOne(ctx, accessToken, a, opts)
+}
+
+// sendOne applies opts to action, sends it alone and turns a false result
+// into an *ActionError.
+func (c *Client) sendOne(ctx context.Context, accessToken string, action Action, opts []ActionOption) error {
+	action.Time = applyActionOptions(opts).time
+
+	_, err := c.Send(ctx, accessToken, []Action{action}, opts...)
+
+	return actionError(action, err)
+}
+
+// actionError turns a *BatchError from sending action into an *ActionError
+// wrapping it, and returns other errors unchanged.
+func actionError(action Action, err error) error {
+	var berr *BatchError
+	if errors.As(err, &berr) {
+		return &ActionError{Action: action.Action, ItemID: action.ItemID, Err: berr}
+	}
+
+	return err
+}
@@ -0,0 +1,426 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertActionError checks that err reports want, caused by the rejected
+// send.
+func assertActionError(t *testing.T, want *ActionError, err error) {
+	t.Helper()
+
+	var aerr *ActionError
+	if assert.ErrorAs(t, err, &aerr) {
+		assert.Equal(t, want.Action, aerr.Action)
+		assert.Equal(t, want.ItemID, aerr.ItemID)
+
+		var berr *BatchError
+		assert.ErrorAs(t, aerr, &berr)
+	}
+}
+
+func TestClient_Archive(t *testing.T) {
+	tests := []struct {
+		name     string
+		itemID   ItemID
+		response string
+		wantBody string
+		wantErr  *ActionError
+		invalid  bool
+	}{
+		{
+			name:     "Archived",
+			itemID:   "229279689",
+			response: `{"status":1,"action_results":[true]}`,
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"archive","item_id":"229279689"}]}`,
+		},
+		{
+			name:     "Rejected",
+			itemID:   "229279689",
+			response: `{"status":1,"action_results":[false]}`,
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"archive","item_id":"229279689"}]}`,
+			wantErr:  &ActionError{Action: ActionArchive, ItemID: "229279689"},
+		},
+		{
+			name:     "Batch failed",
+			itemID:   "1",
+			response: `{"status":0,"action_results":[]}`,
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"archive","item_id":"1"}]}`,
+			wantErr:  &ActionError{Action: ActionArchive, ItemID: "1"},
+		},
+		{name: "Empty item ID", itemID: "", invalid: true},
+		{name: "Non-numeric item ID", itemID: "abc", invalid: true},
+		{name: "Signed item ID", itemID: "-1", invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var responses []string
+			if tt.response != "" {
+				responses = append(responses, tt.response)
+			}
+			client, bodies := newScriptedClient(t, "/v3/send", responses...)
+
+			err := client.Archive(context.Background(), "access-to-ken", tt.itemID)
+
+			if tt.invalid {
+				var verr *ValidationError
+				assert.ErrorAs(t, err, &verr)
+				assert.Empty(t, *bodies)
+				return
+			}
+
+			if tt.wantErr != nil {
+				assertActionError(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if assert.Len(t, *bodies, 1) {
+				assert.JSONEq(t, tt.wantBody, (*bodies)[0])
+			}
+		})
+	}
+}
+
+func TestClient_Readd(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/send",
+		`{"status":1,"action_results":[true]}`,
+		`{"status":1,"action_results":[false]}`,
+	)
+
+	err := client.Readd(context.Background(), "access-to-ken", "42")
+	assert.NoError(t, err)
+
+	err = client.Readd(context.Background(), "access-to-ken", "43")
+	assertActionError(t, &ActionError{Action: ActionReadd, ItemID: "43"}, err)
+
+	if assert.Len(t, *bodies, 2) {
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"readd","item_id":"42"}]}`, (*bodies)[0])
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"readd","item_id":"43"}]}`, (*bodies)[1])
+	}
+
+	err = client.Readd(context.Background(), "access-to-ken", "4 2")
+	var verr *ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Len(t, *bodies, 2)
+}
+
+func TestClient_Favorite(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(c *Client, itemID ItemID) error
+		action   string
+		response string
+		notFound bool
+	}{
+		{
+			name:     "Favorite",
+			call:     func(c *Client, id ItemID) error { return c.Favorite(context.Background(), "access-to-ken", id) },
+			action:   "favorite",
+			response: `{"status":1,"action_results":[true]}`,
+		},
+		{
+			name:     "Favorite unknown item",
+			call:     func(c *Client, id ItemID) error { return c.Favorite(context.Background(), "access-to-ken", id) },
+			action:   "favorite",
+			response: `{"status":1,"action_results":[false]}`,
+			notFound: true,
+		},
+		{
+			name:     "Unfavorite",
+			call:     func(c *Client, id ItemID) error { return c.Unfavorite(context.Background(), "access-to-ken", id) },
+			action:   "unfavorite",
+			response: `{"status":1,"action_results":[true]}`,
+		},
+		{
+			name:     "Unfavorite unknown item",
+			call:     func(c *Client, id ItemID) error { return c.Unfavorite(context.Background(), "access-to-ken", id) },
+			action:   "unfavorite",
+			response: `{"status":1,"action_results":[false]}`,
+			notFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, bodies := newScriptedClient(t, "/v3/send", tt.response)
+
+			err := tt.call(client, "7")
+
+			if tt.notFound {
+				assert.ErrorIs(t, err, ErrItemNotFound)
+
+				var aerr *ActionError
+				if assert.ErrorAs(t, err, &aerr) {
+					assert.Equal(t, ActionType(tt.action), aerr.Action)
+					assert.Equal(t, ItemID("7"), aerr.ItemID)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if assert.Len(t, *bodies, 1) {
+				assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"`+tt.action+`","item_id":"7"}]}`, (*bodies)[0])
+			}
+		})
+	}
+}
+
+func TestClient_Delete(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/send",
+		`{"status":1,"action_results":[true]}`,
+		`{"status":1,"action_results":[false]}`,
+	)
+
+	assert.NoError(t, client.Delete(context.Background(), "access-to-ken", "11"))
+	assertActionError(t, &ActionError{Action: ActionDelete, ItemID: "12"}, client.Delete(context.Background(), "access-to-ken", "12"))
+
+	if assert.Len(t, *bodies, 2) {
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"delete","item_id":"11"}]}`, (*bodies)[0])
+	}
+}
+
+func TestClient_Delete_RequireArchived(t *testing.T) {
+	list := rawPage(
+		`{"item_id":"1","sort_id":0,"status":"0"}`,
+		`{"item_id":"2","sort_id":1,"status":"1"}`,
+	)
+
+	tests := []struct {
+		name    string
+		itemID  ItemID
+		wantErr error
+		sent    bool
+	}{
+		{name: "Archived", itemID: "2", sent: true},
+		{name: "Unread", itemID: "1", wantErr: ErrNotArchived},
+		{name: "Unknown", itemID: "3", wantErr: ErrItemNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []string
+			client := &Client{
+				client: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+					resp := list
+					if r.URL.Path == "/v3/send" {
+						sent = append(sent, readBody(t, r))
+						resp = `{"status":1,"action_results":[true]}`
+					}
+
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(resp))}, nil
+				})},
+				consumerKey: "key",
+			}
+
+			err := client.Delete(context.Background(), "access-to-ken", tt.itemID, WithRequireArchived())
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			}
+
+			if tt.sent {
+				assert.Equal(t, []string{`{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"delete","item_id":"2"}]}`}, sent)
+			} else {
+				assert.Empty(t, sent)
+			}
+		})
+	}
+}
+
+func TestClient_TagsAdd(t *testing.T) {
+	many := make([]string, 30)
+	for i := range many {
+		many[i] = fmt.Sprintf("tag%02d", i)
+	}
+
+	tests := []struct {
+		name     string
+		tags     []string
+		wantTags string
+		invalid  bool
+	}{
+		{name: "Single", tags: []string{"go"}, wantTags: "go"},
+		{name: "Sanitized", tags: []string{" go", "Go", "", "read later"}, wantTags: "go,read later"},
+		{name: "Unicode", tags: []string{"чтение", "日本語", "café"}, wantTags: "чтение,日本語,café"},
+		{name: "Thirty tags", tags: many, wantTags: strings.Join(many, ",")},
+		{name: "Comma", tags: []string{"a,b"}, invalid: true},
+		{name: "Only blanks", tags: []string{" ", ""}, invalid: true},
+		{name: "None", invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var responses []string
+			if !tt.invalid {
+				responses = append(responses, `{"status":1,"action_results":[true]}`)
+			}
+			client, bodies := newScriptedClient(t, "/v3/send", responses...)
+
+			err := client.TagsAdd(context.Background(), "access-to-ken", "5", tt.tags)
+
+			if tt.invalid {
+				var verr *ValidationError
+				assert.ErrorAs(t, err, &verr)
+				assert.Empty(t, *bodies)
+				return
+			}
+
+			assert.NoError(t, err)
+			if assert.Len(t, *bodies, 1) {
+				want, _ := json.Marshal(tt.wantTags)
+				assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"tags_add","item_id":"5","tags":`+string(want)+`}]}`, (*bodies)[0])
+			}
+		})
+	}
+}
+
+func TestClient_TagsRemove(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/send", `{"status":1,"action_results":[true]}`)
+
+	err := client.TagsRemove(context.Background(), "access-to-ken", "5", []string{"go", " never-had-it "})
+	assert.NoError(t, err)
+	if assert.Len(t, *bodies, 1) {
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"tags_remove","item_id":"5","tags":"go,never-had-it"}]}`, (*bodies)[0])
+	}
+
+	err = client.TagsRemove(context.Background(), "access-to-ken", "5", []string{"a,b"})
+	var verr *ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Len(t, *bodies, 1)
+}
+
+func TestClient_TagsReplace(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/send", `{"status":1,"action_results":[true]}`)
+
+	err := client.TagsReplace(context.Background(), "access-to-ken", "5", []string{"go", "archive-2024"})
+	assert.NoError(t, err)
+	if assert.Len(t, *bodies, 1) {
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"tags_replace","item_id":"5","tags":"go,archive-2024"}]}`, (*bodies)[0])
+	}
+
+	for _, tags := range [][]string{nil, {}, {" "}} {
+		err = client.TagsReplace(context.Background(), "access-to-ken", "5", tags)
+
+		var verr *ValidationError
+		if assert.ErrorAs(t, err, &verr) {
+			assert.Equal(t, "Tags", verr.Field)
+			assert.Contains(t, verr.Reason, "TagsClear")
+		}
+	}
+	assert.Len(t, *bodies, 1)
+}
+
+func TestClient_TagsClear(t *testing.T) {
+	tests := []struct {
+		name     string
+		itemID   ItemID
+		response string
+		wantErr  *ActionError
+		invalid  bool
+	}{
+		{name: "Cleared", itemID: "5", response: `{"status":1,"action_results":[true]}`},
+		{name: "Rejected", itemID: "5", response: `{"status":1,"action_results":[false]}`, wantErr: &ActionError{Action: ActionTagsClear, ItemID: "5"}},
+		{name: "Empty item ID", invalid: true},
+		{name: "Non-numeric item ID", itemID: "5a", invalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var responses []string
+			if !tt.invalid {
+				responses = append(responses, tt.response)
+			}
+			client, bodies := newScriptedClient(t, "/v3/send", responses...)
+
+			err := client.TagsClear(context.Background(), "access-to-ken", tt.itemID)
+
+			if tt.invalid {
+				var verr *ValidationError
+				assert.ErrorAs(t, err, &verr)
+				assert.Empty(t, *bodies)
+				return
+			}
+
+			if tt.wantErr != nil {
+				assertActionError(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if assert.Len(t, *bodies, 1) {
+				assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"tags_clear","item_id":"5"}]}`, (*bodies)[0])
+			}
+		})
+	}
+}
+
+func TestClient_WithActionTime(t *testing.T) {
+	at := time.Date(2019, time.May, 4, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		call     func(c *Client) error
+		wantBody string
+	}{
+		{
+			name: "Archive with time",
+			call: func(c *Client) error {
+				return c.Archive(context.Background(), "access-to-ken", "1", WithActionTime(at))
+			},
+			wantBody: `{"action":"archive","item_id":"1","time":1556964000}`,
+		},
+		{
+			name: "Archive with zero time",
+			call: func(c *Client) error {
+				return c.Archive(context.Background(), "access-to-ken", "1", WithActionTime(time.Time{}))
+			},
+			wantBody: `{"action":"archive","item_id":"1"}`,
+		},
+		{
+			name:     "Archive without option",
+			call:     func(c *Client) error { return c.Archive(context.Background(), "access-to-ken", "1") },
+			wantBody: `{"action":"archive","item_id":"1"}`,
+		},
+		{
+			name: "Favorite in another zone",
+			call: func(c *Client) error {
+				return c.Favorite(context.Background(), "access-to-ken", "1", WithActionTime(at.In(time.FixedZone("UTC+3", 3*60*60))))
+			},
+			wantBody: `{"action":"favorite","item_id":"1","time":1556964000}`,
+		},
+		{
+			name: "TagsAdd",
+			call: func(c *Client) error {
+				return c.TagsAdd(context.Background(), "access-to-ken", "1", []string{"go"}, WithActionTime(at))
+			},
+			wantBody: `{"action":"tags_add","item_id":"1","tags":"go","time":1556964000}`,
+		},
+		{
+			name: "TagRename",
+			call: func(c *Client) error {
+				return c.TagRename(context.Background(), "access-to-ken", "a", "b", WithActionTime(at))
+			},
+			wantBody: `{"action":"tag_rename","old_tag":"a","new_tag":"b","time":1556964000}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, bodies := newScriptedClient(t, "/v3/send", `{"status":1,"action_results":[true]}`)
+
+			assert.NoError(t, tt.call(client))
+			if assert.Len(t, *bodies, 1) {
+				assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","actions":[`+tt.wantBody+`]}`, (*bodies)[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package pocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxTitleLength bounds the title of an add, in runes. Longer titles are
+// rejected unless WithTruncateTitle is given.
+const maxTitleLength = 1024
+
+// ErrAlreadySaved is matched by the *AlreadySavedError that Add returns with
+// WithSkipIfExists.
+var ErrAlreadySaved = errors.New("URL is already saved")
+
+// AddOption sets an optional field of an add, see AddURL.
+type AddOption func(*AddInput)
+
+// WithTitle sets the title used until Pocket resolves the page's own.
+func WithTitle(title string) AddOption {
+	return func(i *AddInput) { i.Title = title }
+}
+
+// WithTags sets the tags the item is saved with.
+func WithTags(tags ...string) AddOption {
+	return func(i *AddInput) { i.Tags = tags }
+}
+
+// WithTweetID attributes the save to a tweet.
+func WithTweetID(id string) AddOption {
+	return func(i *AddInput) { i.TweetID = id }
+}
+
+// WithRefID attributes the save to the item it was recommended or
+// syndicated from.
+func WithRefID(refID string) AddOption {
+	return func(i *AddInput) { i.RefID = refID }
+}
+
+// WithAccessToken sets the user the URL is saved for.
+func WithAccessToken(accessToken string) AddOption {
+	return func(i *AddInput) { i.AccessToken = accessToken }
+}
+
+// WithSkipIfExists looks the URL up first, the way GetItemByURL does, and
+// leaves an already saved item alone instead of adding it again, which
+// would move it to the top of the list. Add then fails with an
+// *AlreadySavedError holding the existing item.
+func WithSkipIfExists() AddOption {
+	return func(i *AddInput) { i.skipIfExists = true }
+}
+
+// WithTruncateTitle cuts a title longer than the limit down to size instead
+// of rejecting the add.
+func WithTruncateTitle() AddOption {
+	return func(i *AddInput) { i.truncateTitle = true }
+}
+
+// WithReadd makes Save also move an already saved item back to the top of
+// the unread list. Add ignores it.
+func WithReadd() AddOption {
+	return func(i *AddInput) { i.readd = true }
+}
+
+// AddURL saves rawurl like Add, taking the optional fields as options:
+//
+//	item, err := client.AddURL(ctx, link, pocket.WithAccessToken(token), pocket.WithTags("go"))
+//
+// The input is validated exactly as Add validates an AddInput.
+func (c *Client) AddURL(ctx context.Context, rawurl string, opts ...AddOption) (*AddedItem, error) {
+	return c.Add(ctx, AddInput{URL: rawurl}, opts...)
+}
+
+// cleanTitle trims title and checks it is valid UTF-8 of at most
+// maxTitleLength runes, cutting it down when truncate is set.
+func cleanTitle(title string, truncate bool) (string, error) {
+	title = strings.TrimSpace(title)
+
+	if !utf8.ValidString(title) {
+		return "", &ValidationError{Field: "Title", Reason: "is not valid UTF-8"}
+	}
+
+	n := utf8.RuneCountInString(title)
+	if n <= maxTitleLength {
+		return title, nil
+	}
+
+	if !truncate {
+		return "", &ValidationError{Field: "Title", Reason: fmt.Sprintf("is %d characters long, more than %d", n, maxTitleLength)}
+	}
+
+	return truncateRunes(title, maxTitleLength), nil
+}
+
+// truncateRunes cuts s to at most n runes. A character split from its
+// combining marks by the cut is dropped with them, so no accent goes
+// missing from the last letter.
+func truncateRunes(s string, n int) string {
+	cut := 0
+	for i := range s {
+		if n == 0 {
+			cut = i
+			break
+		}
+		n--
+		cut = len(s)
+	}
+
+	if cut == len(s) {
+		return s
+	}
+
+	r, _ := utf8.DecodeRuneInString(s[cut:])
+	if unicode.Is(unicode.M, r) {
+		for cut > 0 {
+			r, size := utf8.DecodeLastRuneInString(s[:cut])
+			cut -= size
+			if !unicode.Is(unicode.M, r) {
+				break
+			}
+		}
+	}
+
+	return strings.TrimSpace(s[:cut])
+}
@@ -0,0 +1,331 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_AddURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawurl   string
+		opts     []AddOption
+		wantBody string
+		wantErr  string
+	}{
+		{
+			name:     "URL only",
+			rawurl:   "https://example.com",
+			opts:     []AddOption{WithAccessToken("access-to-ken")},
+			wantBody: `{"url":"https://example.com","consumer_key":"key","access_token":"access-to-ken"}`,
+		},
+		{
+			name:   "Every option",
+			rawurl: " https://example.com/a b ",
+			opts: []AddOption{
+				WithAccessToken("access-to-ken"),
+				WithTitle("Title"),
+				WithTags("go", " Go", "read"),
+				WithTweetID("1453256458236891136"),
+				WithRefID("229279689"),
+			},
+			wantBody: `{"url":"https://example.com/a%20b","title":"Title","tags":"go,read","tweet_id":"1453256458236891136","ref_id":"229279689","consumer_key":"key","access_token":"access-to-ken"}`,
+		},
+		{
+			name:     "Later option wins",
+			rawurl:   "https://example.com",
+			opts:     []AddOption{WithAccessToken("access-to-ken"), WithTitle("First"), WithTitle("Second")},
+			wantBody: `{"url":"https://example.com","title":"Second","consumer_key":"key","access_token":"access-to-ken"}`,
+		},
+		{
+			name:    "No access token",
+			rawurl:  "https://example.com",
+			wantErr: "invalid AccessToken: is empty and the client has none; see Client.WithAccessToken",
+		},
+		{
+			name:    "Bad URL",
+			rawurl:  "example.com",
+			opts:    []AddOption{WithAccessToken("access-to-ken")},
+			wantErr: `invalid URL: "example.com" is not an http or https URL`,
+		},
+		{
+			name:    "Comma in tag",
+			rawurl:  "https://example.com",
+			opts:    []AddOption{WithAccessToken("access-to-ken"), WithTags("a,b")},
+			wantErr: `invalid Tags: "a,b" must not contain commas`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			client := newClientWithCheck(t, http.StatusOK, "/v3/add", `{"item":{"item_id":"7"},"status":1}`, func(r *http.Request) {
+				body = readBody(t, r)
+			})
+
+			item, err := client.AddURL(context.Background(), tt.rawurl, tt.opts...)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Empty(t, body)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, ItemID("7"), item.ItemID)
+			assert.Equal(t, tt.wantBody, body)
+		})
+	}
+}
+
+func TestClient_Add_Options(t *testing.T) {
+	var body string
+	client := newClientWithCheck(t, http.StatusOK, "/v3/add", `{"item":{"item_id":"7"},"status":1}`, func(r *http.Request) {
+		body = readBody(t, r)
+	})
+
+	// Options are applied on top of the struct.
+	input := AddInput{URL: "https://example.com", Title: "Struct", Tags: []string{"old"}, AccessToken: "access-to-ken"}
+	_, err := client.Add(context.Background(), input, WithTitle("Option"))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"url":"https://example.com","title":"Option","tags":"old","consumer_key":"key","access_token":"access-to-ken"}`, body)
+	assert.Equal(t, "Struct", input.Title)
+}
+
+func TestClient_Add_SkipIfExists(t *testing.T) {
+	const saved = `{"status":1,"list":{"9":{"item_id":"9","given_url":"http://www.lwn.net/Articles/1/?utm_source=rss","sort_id":0}}}`
+
+	tests := []struct {
+		name      string
+		rawurl    string
+		wantPaths []string
+		wantItem  string
+		wantErr   error
+	}{
+		{
+			name:      "Exists",
+			rawurl:    "http://www.lwn.net/Articles/1/?utm_source=rss",
+			wantPaths: []string{"/v3/get"},
+			wantErr:   ErrAlreadySaved,
+		},
+		{
+			name:      "Normalized match",
+			rawurl:    "https://lwn.net/Articles/1#comments",
+			wantPaths: []string{"/v3/get"},
+			wantErr:   ErrAlreadySaved,
+		},
+		{
+			name:      "Does not exist",
+			rawurl:    "https://lwn.net/Articles/2/",
+			wantPaths: []string{"/v3/get", "/v3/add"},
+			wantItem:  "10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, requests := newRoutedClient(t, map[string]string{
+				"/v3/get": saved,
+				"/v3/add": `{"item":{"item_id":"10"},"status":1}`,
+			})
+
+			item, err := client.AddURL(context.Background(), tt.rawurl, WithAccessToken("access-to-ken"), WithSkipIfExists())
+
+			var paths []string
+			for _, r := range *requests {
+				paths = append(paths, r[0])
+			}
+			assert.Equal(t, tt.wantPaths, paths)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, item)
+
+				var serr *AlreadySavedError
+				if assert.ErrorAs(t, err, &serr) {
+					assert.Equal(t, ItemID("9"), serr.Item.ItemID)
+				}
+				assert.EqualError(t, err, "URL is already saved as item 9")
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, ItemID(tt.wantItem), item.ItemID)
+		})
+	}
+}
+
+func TestClient_Add_SkipIfExists_LookupFails(t *testing.T) {
+	client := newClient(t, http.StatusServiceUnavailable, "/v3/get", "")
+
+	_, err := client.AddURL(context.Background(), "https://lwn.net/", WithAccessToken("access-to-ken"), WithSkipIfExists())
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrAlreadySaved)
+}
+
+func TestClient_Add_ParsedURL(t *testing.T) {
+	mustParse := func(rawurl string) *url.URL {
+		u, err := url.Parse(rawurl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return u
+	}
+
+	tests := []struct {
+		name    string
+		input   AddInput
+		wantURL string
+		wantErr string
+	}{
+		{
+			name:    "Plain",
+			input:   AddInput{ParsedURL: mustParse("https://example.com/a?b=c#d")},
+			wantURL: "https://example.com/a?b=c#d",
+		},
+		{
+			name:    "IDN host",
+			input:   AddInput{ParsedURL: &url.URL{Scheme: "https", Host: "bücher.de", Path: "/a b"}},
+			wantURL: "https://bücher.de/a%20b",
+		},
+		{
+			name:    "Not http",
+			input:   AddInput{ParsedURL: mustParse("ftp://example.com/a")},
+			wantErr: `invalid ParsedURL: "ftp://example.com/a" is not an http or https URL`,
+		},
+		{
+			name:    "Relative",
+			input:   AddInput{ParsedURL: mustParse("/a")},
+			wantErr: `invalid ParsedURL: "/a" is not an http or https URL`,
+		},
+		{
+			name:    "Both set",
+			input:   AddInput{URL: "https://example.com/a", ParsedURL: mustParse("https://example.com/b")},
+			wantErr: "invalid ParsedURL: is set together with URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]any
+			client := newClientWithCheck(t, http.StatusOK, "/v3/add", `{"item":{"item_id":"7"},"status":1}`, func(r *http.Request) {
+				assert.NoError(t, json.Unmarshal([]byte(readBody(t, r)), &body))
+			})
+
+			tt.input.AccessToken = "access-to-ken"
+			item, err := client.Add(context.Background(), tt.input)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, body)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, ItemID("7"), item.ItemID)
+			assert.Equal(t, tt.wantURL, body["url"])
+		})
+	}
+}
+
+func TestCleanTitle(t *testing.T) {
+	long := strings.Repeat("a", maxTitleLength)
+	tenKB := strings.Repeat("0123456789", 1024)
+
+	tests := []struct {
+		name     string
+		title    string
+		truncate bool
+		want     string
+		wantErr  string
+	}{
+		{name: "Empty", title: "", want: ""},
+		{name: "Trimmed", title: "  Title \n", want: "Title"},
+		{name: "Emoji", title: "Go 🐹 gophers 👩‍💻", want: "Go 🐹 gophers 👩‍💻"},
+		{name: "RTL", title: "مرحبا بالعالم", want: "مرحبا بالعالم"},
+		{
+			name:     "Truncated Cyrillic",
+			title:    strings.Repeat("Заголовок", 200),
+			truncate: true,
+			want:     strings.Repeat("Заголовок", 113) + "Заголов",
+		},
+		{name: "At the limit", title: long, want: long},
+		{name: "Emoji at the limit", title: strings.Repeat("🐹", maxTitleLength), want: strings.Repeat("🐹", maxTitleLength)},
+		{
+			name:    "Too long",
+			title:   tenKB,
+			wantErr: "invalid Title: is 10240 characters long, more than 1024",
+		},
+		{
+			name:     "Truncated",
+			title:    tenKB,
+			truncate: true,
+			want:     tenKB[:maxTitleLength],
+		},
+		{
+			name:     "Truncated at an emoji",
+			title:    long[1:] + "🐹🐹",
+			truncate: true,
+			want:     long[1:] + "🐹",
+		},
+		{
+			// "e" followed by a combining acute accent would lose the
+			// accent if cut between the two, so both go.
+			name:     "Truncated before a combining mark",
+			title:    long[1:] + "é",
+			truncate: true,
+			want:     long[1:],
+		},
+		{
+			name:     "Truncated after a combining mark",
+			title:    long[2:] + "éx",
+			truncate: true,
+			want:     long[2:] + "é",
+		},
+		{name: "Invalid UTF-8", title: "bad \xff title", wantErr: "invalid Title: is not valid UTF-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cleanTitle(tt.title, tt.truncate)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, utf8.ValidString(got))
+			assert.LessOrEqual(t, utf8.RuneCountInString(got), maxTitleLength)
+		})
+	}
+}
+
+func TestClient_AddURL_Title(t *testing.T) {
+	const title = "Go 🐹 é مرحبا 👩‍💻"
+
+	var raw string
+	client := newClientWithCheck(t, http.StatusOK, "/v3/add", `{"item":{"item_id":"7"},"status":1}`, func(r *http.Request) {
+		raw = readBody(t, r)
+	})
+
+	_, err := client.AddURL(context.Background(), "https://example.com", WithAccessToken("access-to-ken"), WithTitle(title))
+	assert.NoError(t, err)
+
+	// The title goes out as UTF-8, not as escaped surrogate pairs.
+	assert.Contains(t, raw, `"title":"`+title+`"`)
+	assert.NotContains(t, raw, `\ud83d`)
+
+	_, err = client.AddURL(context.Background(), "https://example.com", WithAccessToken("access-to-ken"),
+		WithTitle(strings.Repeat("x", 10*1024)), WithTruncateTitle())
+	assert.NoError(t, err)
+
+	var body map[string]string
+	assert.NoError(t, json.Unmarshal([]byte(raw), &body))
+	assert.Equal(t, strings.Repeat("x", maxTitleLength), body["title"])
+}
@@ -0,0 +1,155 @@
+package pocket
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// ListTags collects every tag in the account with the number of items
+// carrying it, most used first. Pocket has no endpoint for this, so the whole
+// list is paged through with complete detail.
+func (c *Client) ListTags(ctx context.Context, accessToken string) ([]TagCount, error) {
+	accessToken = c.token(accessToken)
+	if accessToken == "" {
+		return nil, errNoAccessToken()
+	}
+
+	input := GetInput{
+		AccessToken: accessToken,
+		State:       StateAll,
+		Detail:      DetailComplete,
+	}
+
+	counts := make(map[string]int)
+	for item, err := range c.Items(ctx, input) {
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tag := range item.Tags {
+			counts[tag]++
+		}
+	}
+
+	tags := make([]TagCount, 0, len(counts))
+	for tag, count := range counts {
+		tags = append(tags, TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(tags, func(a, b int) bool {
+		if tags[a].Count != tags[b].Count {
+			return tags[a].Count > tags[b].Count
+		}
+		return tags[a].Tag < tags[b].Tag
+	})
+
+	return tags, nil
+}
+
+type DomainCount struct {
+	Domain string
+	Count  int
+}
+
+// ListDomains counts items per host across the whole account, most common
+// first. Hosts are taken from resolved_url, falling back to given_url, and
+// are lowercased with "www." and any port dropped. Items with no usable URL
+// are left out.
+func (c *Client) ListDomains(ctx context.Context, accessToken string) ([]DomainCount, error) {
+	accessToken = c.token(accessToken)
+	if accessToken == "" {
+		return nil, errNoAccessToken()
+	}
+
+	input := GetInput{
+		AccessToken: accessToken,
+		State:       StateAll,
+		Detail:      DetailSimple,
+	}
+
+	counts := make(map[string]int)
+	for item, err := range c.Items(ctx, input) {
+		if err != nil {
+			return nil, err
+		}
+
+		if domain := item.Domain(); domain != "" {
+			counts[domain]++
+		}
+	}
+
+	domains := make([]DomainCount, 0, len(counts))
+	for domain, count := range counts {
+		domains = append(domains, DomainCount{Domain: domain, Count: count})
+	}
+	sort.Slice(domains, func(a, b int) bool {
+		if domains[a].Count != domains[b].Count {
+			return domains[a].Count > domains[b].Count
+		}
+		return domains[a].Domain < domains[b].Domain
+	})
+
+	return domains, nil
+}
+
+type AccountStats struct {
+	Total     int
+	Unread    int
+	Archived  int
+	Favorited int
+	// UnreadReadingTime is the estimated time to read every unread item, see
+	// Item.ReadingTime.
+	UnreadReadingTime time.Duration
+	// OldestUnread is when the oldest unread item was saved, or the zero
+	// time when nothing is unread.
+	OldestUnread time.Time
+}
+
+// Stats summarizes the whole account. Items are streamed page by page with
+// simple detail and never held in memory together.
+func (c *Client) Stats(ctx context.Context, accessToken string) (*AccountStats, error) {
+	accessToken = c.token(accessToken)
+	if accessToken == "" {
+		return nil, errNoAccessToken()
+	}
+
+	input := GetInput{
+		AccessToken: accessToken,
+		State:       StateAll,
+		Detail:      DetailSimple,
+	}
+
+	stats := &AccountStats{}
+	for item, err := range c.Items(ctx, input) {
+		if err != nil {
+			return nil, err
+		}
+
+		if item.IsDeleted() {
+			continue
+		}
+
+		stats.Total++
+		if item.Favorite == 1 {
+			stats.Favorited++
+		}
+
+		if item.IsArchived() {
+			stats.Archived++
+			continue
+		}
+
+		stats.Unread++
+		stats.UnreadReadingTime += item.ReadingTime(defaultWordsPerMinute)
+		if !item.TimeAdded.IsZero() && (stats.OldestUnread.IsZero() || item.TimeAdded.Before(stats.OldestUnread)) {
+			stats.OldestUnread = item.TimeAdded
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,174 @@
+package pocket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rawPage wraps raw item JSON objects, keyed by their position, into a
+// retrieve response.
+func rawPage(items ...string) string {
+	var b strings.Builder
+
+	b.WriteString(`{"status":1,"list":{`)
+	for i, item := range items {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `"%d":%s`, i, item)
+	}
+	b.WriteString(`},"since":1471870000}`)
+
+	return b.String()
+}
+
+// taggedItems returns n raw items starting at ID first, where item i carries
+// the tags returned by tags(i).
+func taggedItems(first, n int, tags func(i int) []string) []string {
+	items := make([]string, 0, n)
+	for i := first; i < first+n; i++ {
+		var t []string
+		for _, tag := range tags(i) {
+			t = append(t, fmt.Sprintf(`"%s":{"item_id":"%d","tag":"%s"}`, tag, i, tag))
+		}
+		items = append(items, fmt.Sprintf(`{"item_id":"%d","sort_id":%d,"tags":{%s}}`, i, i, strings.Join(t, ",")))
+	}
+
+	return items
+}
+
+func TestClient_ListTags(t *testing.T) {
+	tags := func(i int) []string {
+		switch {
+		case i%10 == 0:
+			return []string{"go", "read"}
+		case i%2 == 0:
+			return []string{"go"}
+		case i%7 == 0:
+			return []string{"rust"}
+		}
+		return nil
+	}
+
+	client, bodies := newScriptedClient(t, "/v3/get",
+		rawPage(taggedItems(1, 30, tags)...),
+		rawPage(taggedItems(31, 30, tags)...),
+		rawPage(taggedItems(61, 5, tags)...),
+	)
+
+	got, err := client.ListTags(context.Background(), "access-to-ken")
+	assert.NoError(t, err)
+	assert.Equal(t, []TagCount{
+		{Tag: "go", Count: 32},
+		{Tag: "read", Count: 6},
+		{Tag: "rust", Count: 5},
+	}, got)
+
+	if assert.Len(t, *bodies, 3) {
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","count":30,"offset":60,"state":"all","detailType":"complete"}`, (*bodies)[2])
+	}
+}
+
+func TestClient_ListTags_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client, bodies := newScriptedClient(t, "/v3/get")
+
+	_, err := client.ListTags(ctx, "access-to-ken")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, *bodies)
+}
+
+func TestClient_ListTags_EmptyAccessToken(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/get")
+
+	_, err := client.ListTags(context.Background(), "")
+	assert.Error(t, err)
+	assert.Empty(t, *bodies)
+}
+
+func TestClient_ListDomains(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/get", rawPage(
+		`{"item_id":"1","given_url":"https://lwn.net/Articles/1/","sort_id":0}`,
+		`{"item_id":"2","given_url":"http://t.co/x","resolved_url":"https://www.LWN.net/Articles/2/","sort_id":1}`,
+		`{"item_id":"3","given_url":"https://go.dev:443/blog","sort_id":2}`,
+		`{"item_id":"4","given_url":"http://[::1","sort_id":3}`,
+		`{"item_id":"5","given_url":"http://192.168.0.1:8080/a","sort_id":4}`,
+		`{"item_id":"6","given_url":"https://go.dev/doc","sort_id":5}`,
+		`{"item_id":"7","given_url":"https://lwn.net/Articles/3/","sort_id":6}`,
+	))
+
+	got, err := client.ListDomains(context.Background(), "access-to-ken")
+	assert.NoError(t, err)
+	assert.Equal(t, []DomainCount{
+		{Domain: "lwn.net", Count: 3},
+		{Domain: "go.dev", Count: 2},
+		{Domain: "192.168.0.1", Count: 1},
+	}, got)
+
+	if assert.Len(t, *bodies, 1) {
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","count":30,"state":"all","detailType":"simple"}`, (*bodies)[0])
+	}
+}
+
+func TestClient_Stats(t *testing.T) {
+	unread := func(id, added, words int, favorite string) string {
+		return fmt.Sprintf(`{"item_id":"%d","sort_id":%d,"status":"0","favorite":"%s","time_added":"%d","word_count":"%d"}`, id, id, favorite, added, words)
+	}
+
+	first := make([]string, 0, 30)
+	for i := range 30 {
+		first = append(first, unread(i, 1700000000+i, 400, "0"))
+	}
+
+	client, bodies := newScriptedClient(t, "/v3/get",
+		rawPage(first...),
+		rawPage(
+			unread(30, 1600000000, 1000, "1"),
+			`{"item_id":"31","sort_id":31,"status":"1","favorite":"1","time_added":"1500000000","word_count":"5000"}`,
+			`{"item_id":"32","sort_id":32,"status":"1","favorite":"0","time_added":"1400000000"}`,
+			`{"item_id":"33","sort_id":33,"status":"2","favorite":"1","time_added":"1300000000"}`,
+			`{"item_id":"34","sort_id":34,"status":"0","favorite":"0","time_added":"0","time_to_read":7}`,
+		),
+	)
+
+	got, err := client.Stats(context.Background(), "access-to-ken")
+	assert.NoError(t, err)
+	assert.Equal(t, &AccountStats{
+		Total:             34,
+		Unread:            32,
+		Archived:          2,
+		Favorited:         2,
+		UnreadReadingTime: 30*2*time.Minute + 5*time.Minute + 7*time.Minute,
+		OldestUnread:      time.Unix(1600000000, 0).UTC(),
+	}, got)
+
+	if assert.Len(t, *bodies, 2) {
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","count":30,"offset":30,"state":"all","detailType":"simple"}`, (*bodies)[1])
+	}
+}
+
+func TestClient_Stats_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client, bodies := newScriptedClient(t, "/v3/get")
+
+	_, err := client.Stats(ctx, "access-to-ken")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, *bodies)
+}
+
+func TestClient_Stats_Empty(t *testing.T) {
+	client, _ := newScriptedClient(t, "/v3/get", `{"status":2,"list":[],"since":1471870000}`)
+
+	got, err := client.Stats(context.Background(), "access-to-ken")
+	assert.NoError(t, err)
+	assert.Equal(t, &AccountStats{}, got)
+}
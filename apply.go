@@ -0,0 +1,249 @@
+package pocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DesiredItem is the state Apply brings a URL to.
+type DesiredItem struct {
+	URL      string
+	Archived bool
+	Favorite bool
+	// Tags is the exact tag set the item ends up with; an empty Tags removes
+	// every tag.
+	Tags []string
+}
+
+// Apply reconciles the account with desired. The saved items are retrieved
+// once and matched by URL like GetItemByURL does, and only the actions
+// needed to close the gap are sent: unchanged items cost nothing. URLs not
+// saved yet are added with their tags first, then archived or favorited
+// using the item Pocket returns for the add. When Pocket only acknowledges
+// an add without the item, the item is looked up by URL; failing that, the
+// returned error says so.
+//
+// The response lists the adds before every other action, and a *BatchError
+// indexes into that order. With WithDryRun, follow-up actions for URLs that
+// would be added are left out, since they need the new item IDs.
+func (c *Client) Apply(ctx context.Context, accessToken string, desired []DesiredItem, opts ...ActionOption) (*SendResponse, error) {
+	accessToken = c.token(accessToken)
+	if accessToken == "" {
+		return nil, errNoAccessToken()
+	}
+
+	wants := make(map[string]DesiredItem, len(desired))
+	keys := make([]string, len(desired))
+	for i, want := range desired {
+		key, err := normalizeURL(want.URL)
+		if err != nil {
+			return nil, fmt.Errorf("desired[%d]: %w", i, err)
+		}
+
+		if _, ok := wants[key]; ok {
+			return nil, &ValidationError{Field: fmt.Sprintf("desired[%d].URL", i), Reason: fmt.Sprintf("%q is listed twice", want.URL)}
+		}
+
+		if want.Tags, err = sanitizeTags(want.Tags); err != nil {
+			return nil, fmt.Errorf("desired[%d]: %w", i, err)
+		}
+
+		wants[key] = want
+		keys[i] = key
+	}
+
+	saved, err := c.savedByURL(ctx, accessToken, wants)
+	if err != nil {
+		return nil, err
+	}
+
+	var adds, updates []Action
+	var added []DesiredItem
+	for _, key := range keys {
+		want := wants[key]
+
+		item, ok := saved[key]
+		if !ok {
+			action, err := newAddAction(AddInput{URL: want.URL, Tags: want.Tags})
+			if err != nil {
+				return nil, err
+			}
+			adds = append(adds, action)
+			added = append(added, want)
+			continue
+		}
+
+		updates = append(updates, diffItem(item, want)...)
+	}
+
+	if len(adds)+len(updates) == 0 {
+		return &SendResponse{Status: 1}, nil
+	}
+
+	if c.dryRunning(opts) {
+		return c.Send(ctx, accessToken, append(adds, updates...), opts...)
+	}
+
+	merged := &SendResponse{Status: 1}
+	var lookupErrs []error
+	if len(adds) > 0 {
+		resp, err := c.sendChunked(ctx, accessToken, adds, opts)
+
+		var berr *BatchError
+		if err != nil && !errors.As(err, &berr) {
+			return nil, err
+		}
+		mergeResults(merged, resp, 0)
+
+		// A new item is unread, not a favorite and already carries its tags.
+		for i, result := range resp.Results {
+			if !result.OK {
+				continue
+			}
+
+			want := added[i]
+			item := Item{Tags: want.Tags}
+			if len(diffItem(item, want)) == 0 {
+				continue
+			}
+
+			if result.Item != nil {
+				item.ItemID = result.Item.ItemID
+			} else {
+				found, err := c.findItemByURL(ctx, accessToken, want.URL, DetailSimple)
+				if err != nil {
+					lookupErrs = append(lookupErrs, fmt.Errorf("look up added %s: %w", want.URL, err))
+					continue
+				}
+				item.ItemID = found.ItemID
+			}
+			updates = append(updates, diffItem(item, want)...)
+		}
+	}
+
+	if len(updates) > 0 {
+		resp, err := c.sendChunked(ctx, accessToken, updates, opts)
+
+		var berr *BatchError
+		if err != nil && !errors.As(err, &berr) {
+			return merged, err
+		}
+		mergeResults(merged, resp, len(adds))
+	}
+
+	sent := append(adds, updates...)
+	if berr := batchError(sent, merged); berr != nil {
+		if len(lookupErrs) == 0 {
+			return merged, berr
+		}
+		lookupErrs = append([]error{berr}, lookupErrs...)
+	}
+
+	return merged, errors.Join(lookupErrs...)
+}
+
+// savedByURL retrieves the account once and returns the items matching the
+// normalized URLs in wants. Deleted items do not count as saved.
+func (c *Client) savedByURL(ctx context.Context, accessToken string, wants map[string]DesiredItem) (map[string]Item, error) {
+	input := GetInput{
+		AccessToken: accessToken,
+		State:       StateAll,
+		Detail:      DetailComplete,
+	}
+
+	saved := make(map[string]Item)
+	for item, err := range c.Items(ctx, input) {
+		if err != nil {
+			return nil, err
+		}
+
+		if item.IsDeleted() {
+			continue
+		}
+
+		for _, candidate := range []string{item.GivenURL, item.ResolvedURL} {
+			key, err := normalizeURL(candidate)
+			if err != nil {
+				continue
+			}
+
+			if _, ok := wants[key]; !ok {
+				continue
+			}
+			if _, ok := saved[key]; !ok {
+				saved[key] = item
+			}
+		}
+	}
+
+	return saved, nil
+}
+
+// diffItem returns the actions turning item into want: tags first, then the
+// favorite mark, then the archive state. want.Tags must be sanitized.
+func diffItem(item Item, want DesiredItem) []Action {
+	var actions []Action
+
+	if !sameTags(item.Tags, want.Tags) {
+		if len(want.Tags) == 0 {
+			actions = append(actions, Action{Action: ActionTagsClear, ItemID: item.ItemID})
+		} else {
+			actions = append(actions, Action{Action: ActionTagsReplace, ItemID: item.ItemID, Tags: want.Tags})
+		}
+	}
+
+	if isFavorite := item.Favorite == 1; isFavorite != want.Favorite {
+		action := ActionFavorite
+		if isFavorite {
+			action = ActionUnfavorite
+		}
+		actions = append(actions, Action{Action: action, ItemID: item.ItemID})
+	}
+
+	if item.IsArchived() != want.Archived {
+		action := ActionArchive
+		if item.IsArchived() {
+			action = ActionReadd
+		}
+		actions = append(actions, Action{Action: action, ItemID: item.ItemID})
+	}
+
+	return actions
+}
+
+// sameTags compares tag sets the way Pocket stores them: ignoring order,
+// surrounding space, duplicates and case, as sanitizeTags does.
+func sameTags(a, b []string) bool {
+	return slices.Equal(tagKeys(a), tagKeys(b))
+}
+
+// tagKeys returns the sorted, case folded tags of sanitizeTags(tags).
+func tagKeys(tags []string) []string {
+	keys, err := sanitizeTags(tags)
+	if err != nil {
+		keys = slices.Clone(tags)
+	}
+
+	for i, key := range keys {
+		keys[i] = strings.ToLower(key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+// mergeResults appends resp's results to merged, shifting their indexes by
+// offset.
+func mergeResults(merged, resp *SendResponse, offset int) {
+	if resp.Status != 1 {
+		merged.Status = resp.Status
+	}
+
+	for _, result := range resp.Results {
+		result.Index += offset
+		merged.Results = append(merged.Results, result)
+	}
+}
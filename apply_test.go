@@ -0,0 +1,186 @@
+package pocket
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newApplyAccount() *fakeAccount {
+	a := newFakeAccount(0)
+	a.items = []Item{
+		{ItemID: "1", GivenURL: "https://example.com/unchanged", Status: ItemStatusArchived, Favorite: 1, Tags: []string{"go", "reading"}},
+		{ItemID: "2", GivenURL: "https://example.com/retag", Status: ItemStatusUnread, Tags: []string{"old"}},
+		{ItemID: "3", GivenURL: "http://www.example.com/flip/", Status: ItemStatusArchived, Favorite: 1},
+		{ItemID: "4", GivenURL: "https://example.com/gone", Status: ItemStatusDeleted},
+	}
+
+	return a
+}
+
+func TestClient_Apply(t *testing.T) {
+	account := newApplyAccount()
+	client := account.client(t)
+
+	desired := []DesiredItem{
+		{URL: "https://example.com/unchanged", Archived: true, Favorite: true, Tags: []string{"reading", "go"}},
+		{URL: "https://example.com/retag", Tags: []string{"new"}},
+		{URL: "https://example.com/flip"},
+		{URL: "https://example.com/gone", Archived: true, Tags: []string{"go"}},
+		{URL: "https://example.com/fresh", Favorite: true},
+	}
+
+	resp, err := client.Apply(context.Background(), "access-to-ken", desired)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.Status)
+	assert.Len(t, resp.Results, 7)
+
+	// Two adds, then retag, unfavorite and readd for the flip, then the
+	// follow-ups of the adds.
+	assert.Equal(t, []string{
+		"https://example.com/gone", "https://example.com/fresh",
+		"2", "3", "3", "5", "6",
+	}, account.actioned)
+	assert.Equal(t, 2, account.sends)
+	assert.Equal(t, 1, account.gets)
+
+	assert.Equal(t, []string{"new"}, account.items[1].Tags)
+	assert.True(t, account.items[2].IsUnread())
+	assert.Equal(t, 0, account.items[2].Favorite)
+	assert.True(t, account.items[4].IsArchived())
+	assert.Equal(t, []string{"go"}, account.items[4].Tags)
+	assert.Equal(t, 1, account.items[5].Favorite)
+
+	// Applying again finds nothing to do.
+	account.sends = 0
+	resp, err = client.Apply(context.Background(), "access-to-ken", desired)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Results)
+	assert.Equal(t, 0, account.sends)
+}
+
+func TestClient_Apply_ClearTags(t *testing.T) {
+	account := newApplyAccount()
+	client := account.client(t)
+
+	_, err := client.Apply(context.Background(), "access-to-ken", []DesiredItem{
+		{URL: "https://example.com/retag"},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, account.items[1].Tags)
+}
+
+func TestClient_Apply_PlainAddResult(t *testing.T) {
+	account := newApplyAccount()
+	account.plainAdds = true
+	client := account.client(t)
+
+	resp, err := client.Apply(context.Background(), "access-to-ken", []DesiredItem{
+		{URL: "https://example.com/fresh", Archived: true, Favorite: true},
+		{URL: "https://example.com/plain"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.Status)
+
+	// The follow-ups reach the new item although Pocket did not return it.
+	assert.Equal(t, []string{"https://example.com/fresh", "https://example.com/plain", "5", "5"}, account.actioned)
+	assert.Equal(t, 1, account.items[4].Favorite)
+	assert.True(t, account.items[4].IsArchived())
+	// The second add needs no follow-up and no lookup.
+	assert.Equal(t, 2, account.gets)
+}
+
+func TestClient_Apply_PlainAddNotFound(t *testing.T) {
+	// Pocket acknowledges the add but the item does not show up.
+	client, requests := newRoutedClient(t, map[string]string{
+		"/v3/get":  `{"status":1,"list":[]}`,
+		"/v3/send": `{"status":1,"action_results":[true]}`,
+	})
+
+	resp, err := client.Apply(context.Background(), "access-to-ken", []DesiredItem{
+		{URL: "https://example.com/fresh", Favorite: true},
+	})
+	assert.ErrorIs(t, err, ErrItemNotFound)
+	assert.ErrorContains(t, err, "https://example.com/fresh")
+	assert.Len(t, resp.Results, 1)
+	assert.Len(t, *requests, 3)
+}
+
+func TestClient_Apply_TagCase(t *testing.T) {
+	account := newApplyAccount()
+	client := account.client(t)
+
+	// Pocket folds tag case, so differently cased tags are already in place.
+	resp, err := client.Apply(context.Background(), "access-to-ken", []DesiredItem{
+		{URL: "https://example.com/unchanged", Archived: true, Favorite: true, Tags: []string{"Reading", " GO", "go"}},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Results)
+	assert.Equal(t, 0, account.sends)
+}
+
+func TestClient_Apply_DryRun(t *testing.T) {
+	account := newApplyAccount()
+	client := account.client(t)
+
+	resp, err := client.Apply(context.Background(), "access-to-ken", []DesiredItem{
+		{URL: "https://example.com/flip", Archived: true},
+		{URL: "https://example.com/fresh", Archived: true},
+	}, WithDryRun())
+	assert.NoError(t, err)
+	assert.True(t, resp.DryRun)
+	assert.Equal(t, []Action{
+		{Action: ActionAdd, URL: "https://example.com/fresh", Tags: []string{}},
+		{Action: ActionUnfavorite, ItemID: "3"},
+	}, resp.Actions)
+	assert.Equal(t, 0, account.sends)
+}
+
+func TestClient_Apply_Rejected(t *testing.T) {
+	account := newApplyAccount()
+	account.reject = func(id string) bool { return id == "3" }
+	client := account.client(t)
+
+	resp, err := client.Apply(context.Background(), "access-to-ken", []DesiredItem{
+		{URL: "https://example.com/retag", Tags: []string{"new"}},
+		{URL: "https://example.com/flip", Archived: true, Favorite: true, Tags: []string{"x"}},
+	})
+
+	var berr *BatchError
+	if assert.True(t, errors.As(err, &berr)) {
+		assert.Equal(t, []int{1}, berr.Failed)
+		assert.Equal(t, []Action{{Action: ActionTagsReplace, ItemID: "3", Tags: []string{"x"}}}, berr.Actions)
+	}
+	assert.Len(t, resp.Results, 2)
+}
+
+func TestClient_Apply_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		accessToken string
+		desired     []DesiredItem
+	}{
+		{name: "Empty token", desired: []DesiredItem{{URL: "https://example.com/"}}},
+		{name: "Relative URL", accessToken: "access-to-ken", desired: []DesiredItem{{URL: "example"}}},
+		{name: "Comma in tag", accessToken: "access-to-ken", desired: []DesiredItem{{URL: "https://example.com/", Tags: []string{"a,b"}}}},
+		{
+			name:        "Duplicate URL",
+			accessToken: "access-to-ken",
+			desired:     []DesiredItem{{URL: "https://example.com/a"}, {URL: "http://www.example.com/a/"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := newApplyAccount()
+			client := account.client(t)
+
+			_, err := client.Apply(context.Background(), tt.accessToken, tt.desired)
+			assert.Error(t, err)
+			assert.Equal(t, 0, account.gets)
+			assert.Equal(t, 0, account.sends)
+		})
+	}
+}
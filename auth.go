@@ -0,0 +1,158 @@
+package pocket
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// RequestTokenOption adjusts GetRequestToken.
+type RequestTokenOption func(*requestTokenRequest)
+
+// WithOAuthState sends state along with the request token request. Pocket
+// echoes it back, and an app keeps it, typically in the user's session, to
+// check with VerifyState that the callback belongs to a flow it started.
+// NewState makes a suitable value.
+func WithOAuthState(state string) RequestTokenOption {
+	return func(r *requestTokenRequest) {
+		r.State = state
+	}
+}
+
+// ErrStateMismatch is returned by VerifyState when the states differ.
+var ErrStateMismatch = errors.New("OAuth state mismatch")
+
+// NewState returns a random value for WithOAuthState.
+func NewState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// VerifyState checks the state of a callback against the expected one in
+// constant time, returning ErrStateMismatch when they differ. An empty
+// expected state never matches, so a lost session cannot pass the check.
+func VerifyState(expected, got string) error {
+	if expected == "" {
+		return &ValidationError{Field: "expected", Reason: "is empty"}
+	}
+
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(got)) != 1 {
+		return ErrStateMismatch
+	}
+
+	return nil
+}
+
+// Permission is an access level a Pocket app can be registered with.
+type Permission string
+
+const (
+	PermissionAdd      Permission = "add"
+	PermissionModify   Permission = "modify"
+	PermissionRetrieve Permission = "retrieve"
+)
+
+func (p Permission) valid() bool {
+	switch p {
+	case PermissionAdd, PermissionModify, PermissionRetrieve:
+		return true
+	}
+
+	return false
+}
+
+// AuthURLOption adjusts the page GetAuthorizationURL points to.
+type AuthURLOption func(*authURLOptions)
+
+type authURLOptions struct {
+	mobile      bool
+	forceLogin  bool
+	permissions []Permission
+}
+
+// WithMobile asks for the mobile layout of the authorization page, which
+// fits webviews and in-app browsers.
+func WithMobile() AuthURLOption {
+	return func(o *authURLOptions) {
+		o.mobile = true
+	}
+}
+
+// WithForceLogin always shows the login form, even when the browser is
+// still signed in to Pocket. Shared devices need it so that the next user
+// does not authorize the app on the previous user's account.
+func WithForceLogin() AuthURLOption {
+	return func(o *authURLOptions) {
+		o.forceLogin = true
+	}
+}
+
+// WithPermissions tells the authorization page which permissions the app
+// will use, as a hint; what the app may do is still decided by how it is
+// registered. Repeated permissions are listed once.
+func WithPermissions(perms ...Permission) AuthURLOption {
+	return func(o *authURLOptions) {
+		o.permissions = append([]Permission{}, perms...)
+	}
+}
+
+func applyAuthURLOptions(opts []AuthURLOption) authURLOptions {
+	var o authURLOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// query adds the options to an authorization URL query, rejecting an empty
+// list or unknown permissions.
+func (o authURLOptions) query(query url.Values) error {
+	if o.mobile {
+		query.Set("mobile", "1")
+	}
+
+	if o.forceLogin {
+		query.Set("force", "login")
+	}
+
+	if o.permissions != nil {
+		if len(o.permissions) == 0 {
+			return &ValidationError{Field: "permissions", Reason: "is empty"}
+		}
+
+		names := make([]string, 0, len(o.permissions))
+		for _, p := range o.permissions {
+			if !p.valid() {
+				return &ValidationError{Field: "permissions", Reason: fmt.Sprintf("unknown permission %q", p)}
+			}
+			if !slices.Contains(names, string(p)) {
+				names = append(names, string(p))
+			}
+		}
+
+		query.Set("permissions", strings.Join(names, ","))
+	}
+
+	return nil
+}
+
+// ValidateToken checks that accessToken still works by retrieving a single
+// item. A token Pocket rejects yields an error matching ErrInvalidToken or
+// ErrTokenRevoked with errors.Is; any other failure, such as a rate limit
+// or a network error, says nothing about the token.
+func (c *Client) ValidateToken(ctx context.Context, accessToken string) error {
+	_, err := c.Get(ctx, GetInput{AccessToken: accessToken, Count: 1, Detail: DetailSimple})
+
+	return err
+}
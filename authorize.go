@@ -0,0 +1,136 @@
+package pocket
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const callbackPath = "/callback"
+
+// AuthorizeOption adjusts Authorize.
+type AuthorizeOption func(*authorizeOptions)
+
+type authorizeOptions struct {
+	addr    string
+	openURL func(string) error
+	authURL []AuthURLOption
+}
+
+// WithCallbackAddr sets the address the callback listener binds to. The
+// default is a random port on 127.0.0.1; a fixed one is needed when the app
+// is registered with an exact redirect URI.
+func WithCallbackAddr(addr string) AuthorizeOption {
+	return func(o *authorizeOptions) {
+		o.addr = addr
+	}
+}
+
+// WithOpenURL sets how the authorization page is shown to the user, such as
+// by launching a browser. By default the URL is printed to stdout.
+func WithOpenURL(open func(string) error) AuthorizeOption {
+	return func(o *authorizeOptions) {
+		o.openURL = open
+	}
+}
+
+// WithAuthURLOptions passes opts on to GetAuthorizationURL.
+func WithAuthURLOptions(opts ...AuthURLOption) AuthorizeOption {
+	return func(o *authorizeOptions) {
+		o.authURL = append(o.authURL, opts...)
+	}
+}
+
+// Authorize runs the whole OAuth flow for a command line app. It listens on
+// localhost for the redirect, gets a request token, opens the authorization
+// page and, once the browser comes back, exchanges the token for an access
+// token.
+//
+// Authorize waits until the callback arrives or ctx is done, so ctx should
+// carry a timeout for users who abandon the flow. The redirect carries a
+// fresh state; callbacks without it are rejected, and repeated ones, such
+// as a reloaded page, are answered but ignored.
+func (c *Client) Authorize(ctx context.Context, opts ...AuthorizeOption) (*AuthorizeResponse, error) {
+	o := authorizeOptions{
+		addr: "127.0.0.1:0",
+		openURL: func(u string) error {
+			_, err := fmt.Printf("Open this URL to authorize the app:\n%s\n", u)
+			return err
+		},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	state, err := NewState()
+	if err != nil {
+		return nil, fmt.Errorf("generate state: %w", err)
+	}
+
+	ln, err := net.Listen("tcp", o.addr)
+	if err != nil {
+		return nil, fmt.Errorf("listen for the callback on %s: %w", o.addr, err)
+	}
+
+	callbacks := make(chan struct{}, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+callbackPath, func(w http.ResponseWriter, r *http.Request) {
+		if err := VerifyState(state, r.URL.Query().Get("state")); err != nil {
+			http.Error(w, "Unknown authorization request.", http.StatusBadRequest)
+			return
+		}
+
+		select {
+		case callbacks <- struct{}{}:
+		default:
+		}
+
+		fmt.Fprintln(w, "Authorization received, you can close this window.")
+	})
+
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go srv.Serve(ln)
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	redirect := (&url.URL{
+		Scheme:   "http",
+		Host:     ln.Addr().String(),
+		Path:     callbackPath,
+		RawQuery: url.Values{"state": {state}}.Encode(),
+	}).String()
+
+	code, err := c.GetRequestTokenResponse(ctx, redirect, WithOAuthState(state))
+	if err != nil {
+		return nil, err
+	}
+
+	if code.State != "" {
+		if err := VerifyState(state, code.State); err != nil {
+			return nil, err
+		}
+	}
+
+	authURL, err := c.GetAuthorizationURL(ctx, code.Code, redirect, o.authURL...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := o.openURL(authURL); err != nil {
+		return nil, fmt.Errorf("open authorization URL: %w", err)
+	}
+
+	select {
+	case <-callbacks:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("wait for the authorization callback: %w", ctx.Err())
+	}
+
+	return c.GetAuthorizeResponse(ctx, code.Code)
+}
@@ -0,0 +1,109 @@
+package pocket
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeBrowser returns an OpenURL that follows the redirect of the
+// authorization page to each of the given states, "" standing for the
+// state Authorize put in the redirect, and records the status codes.
+func fakeBrowser(t *testing.T, statuses *[]int, states ...string) func(string) error {
+	return func(authURL string) error {
+		u, err := url.Parse(authURL)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, "qwe-rty-123", u.Query().Get("request_token"))
+
+		redirect, err := url.Parse(u.Query().Get("redirect_uri"))
+		if err != nil {
+			return err
+		}
+
+		for _, state := range states {
+			callback := *redirect
+			if state != "" {
+				callback.RawQuery = url.Values{"state": {state}}.Encode()
+			}
+
+			resp, err := http.Get(callback.String())
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			*statuses = append(*statuses, resp.StatusCode)
+		}
+
+		return nil
+	}
+}
+
+func newAuthorizeClient(t *testing.T) (*Client, *[][2]string) {
+	return newRoutedClient(t, map[string]string{
+		"/v3/oauth/request":   `{"code":"qwe-rty-123"}`,
+		"/v3/oauth/authorize": `{"access_token":"acc-123","username":"pocketuser"}`,
+	})
+}
+
+func TestClient_Authorize(t *testing.T) {
+	client, requests := newAuthorizeClient(t)
+
+	var statuses []int
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	got, err := client.Authorize(ctx, WithOpenURL(fakeBrowser(t, &statuses, "forged", "", "")))
+	assert.NoError(t, err)
+	assert.Equal(t, &AuthorizeResponse{AccessToken: "acc-123", Username: "pocketuser"}, got)
+
+	// The forged callback is rejected and the reload is answered once more.
+	assert.Equal(t, []int{http.StatusBadRequest, http.StatusOK, http.StatusOK}, statuses)
+
+	if !assert.Len(t, *requests, 2) {
+		return
+	}
+	assert.Contains(t, (*requests)[0][1], `"redirect_uri":"http://127.0.0.1:`)
+	assert.Contains(t, (*requests)[0][1], `"state":"`)
+	assert.JSONEq(t, `{"consumer_key":"key","code":"qwe-rty-123"}`, (*requests)[1][1])
+}
+
+func TestClient_Authorize_Timeout(t *testing.T) {
+	client, requests := newAuthorizeClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Authorize(ctx, WithOpenURL(func(string) error { return nil }))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Len(t, *requests, 1)
+}
+
+func TestClient_Authorize_PortInUse(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	client, requests := newAuthorizeClient(t)
+
+	_, err = client.Authorize(context.Background(), WithCallbackAddr(ln.Addr().String()))
+	assert.ErrorContains(t, err, "listen for the callback")
+	assert.Empty(t, *requests)
+}
+
+func TestClient_Authorize_OpenURLError(t *testing.T) {
+	client, _ := newAuthorizeClient(t)
+	errNoBrowser := errors.New("no browser")
+
+	_, err := client.Authorize(context.Background(), WithOpenURL(func(string) error { return errNoBrowser }))
+	assert.ErrorIs(t, err, errNoBrowser)
+}
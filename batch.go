@@ -0,0 +1,78 @@
+package pocket
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// AddResult is the outcome of one input to AddBatch: the saved item, or the
+// error that kept it from being saved.
+type AddResult struct {
+	Input AddInput
+	Item  *AddedItem
+	Err   error
+}
+
+// AddBatch saves inputs through Add with up to concurrency requests in
+// flight, so each URL gets its own result and item. A concurrency below 1
+// means one at a time. The SDK does not rate limit requests itself, so
+// concurrency is what keeps a large import from arriving in one burst.
+//
+// Results are in input order. Once ctx is cancelled no further inputs are
+// started; their results carry the context error, which is also returned.
+// Otherwise a failed input does not stop the others, and the returned error
+// only reports how many failed.
+func (c *Client) AddBatch(ctx context.Context, inputs []AddInput, concurrency int) ([]AddResult, error) {
+	results := make([]AddResult, len(inputs))
+	for i, input := range inputs {
+		results[i].Input = input
+	}
+
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range max(1, min(concurrency, len(inputs))) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				results[i].Item, results[i].Err = c.Add(ctx, inputs[i])
+			}
+		}()
+	}
+
+	var skipped error
+	for i := range inputs {
+		if ctx.Err() == nil {
+			select {
+			case jobs <- i:
+				continue
+			case <-ctx.Done():
+			}
+		}
+
+		skipped = ctx.Err()
+		results[i].Err = skipped
+	}
+	close(jobs)
+	wg.Wait()
+
+	if skipped != nil {
+		return results, skipped
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("%d of %d adds failed", failed, len(inputs))
+	}
+
+	return results, nil
+}
@@ -0,0 +1,165 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newAddBatchClient answers add requests with the item ID taken from the
+// URL's last path segment, failing URLs containing "fail". Every request is
+// held for a moment so concurrent ones overlap.
+func newAddBatchClient(t *testing.T, peak *atomic.Int32, started func(url string)) *Client {
+	var inFlight atomic.Int32
+
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		var req addRequest
+		assert.NoError(t, json.Unmarshal([]byte(readBody(t, r)), &req))
+		if started != nil {
+			started(req.Url)
+		}
+
+		time.Sleep(5 * time.Millisecond)
+
+		if strings.Contains(req.Url, "fail") {
+			header := http.Header{}
+			header.Set(xErrorHeader, "Invalid URL")
+			return &http.Response{StatusCode: http.StatusBadRequest, Header: header, Body: io.NopCloser(strings.NewReader(""))}, nil
+		}
+
+		id := req.Url[strings.LastIndex(req.Url, "/")+1:]
+		body := fmt.Sprintf(`{"item":{"item_id":%q,"given_url":%q},"status":1}`, id, req.Url)
+
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+
+	return &Client{client: &http.Client{Transport: transport}, consumerKey: "key"}
+}
+
+func addBatchInputs(urls ...string) []AddInput {
+	inputs := make([]AddInput, len(urls))
+	for i, u := range urls {
+		inputs[i] = AddInput{URL: u, AccessToken: "access-to-ken"}
+	}
+
+	return inputs
+}
+
+func TestClient_AddBatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency int
+		wantPeak    int32
+	}{
+		{name: "Serial", concurrency: 1, wantPeak: 1},
+		{name: "Below 1", concurrency: 0, wantPeak: 1},
+		{name: "Bounded", concurrency: 3, wantPeak: 3},
+		{name: "More workers than inputs", concurrency: 50, wantPeak: 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var urls []string
+			for i := 1; i <= 12; i++ {
+				urls = append(urls, fmt.Sprintf("https://example.com/%d", i))
+			}
+
+			var peak atomic.Int32
+			client := newAddBatchClient(t, &peak, nil)
+
+			results, err := client.AddBatch(context.Background(), addBatchInputs(urls...), tt.concurrency)
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, peak.Load(), tt.wantPeak)
+
+			if assert.Len(t, results, len(urls)) {
+				for i, result := range results {
+					assert.NoError(t, result.Err)
+					assert.Equal(t, urls[i], result.Input.URL)
+					assert.Equal(t, ItemID(fmt.Sprint(i+1)), result.Item.ItemID)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_AddBatch_PartialFailure(t *testing.T) {
+	var peak atomic.Int32
+	client := newAddBatchClient(t, &peak, nil)
+
+	inputs := addBatchInputs("https://example.com/1", "https://example.com/fail", "not a url", "https://example.com/4")
+	results, err := client.AddBatch(context.Background(), inputs, 2)
+	assert.EqualError(t, err, "2 of 4 adds failed")
+
+	if assert.Len(t, results, 4) {
+		assert.Equal(t, ItemID("1"), results[0].Item.ItemID)
+		assert.ErrorContains(t, results[1].Err, "Invalid URL")
+		assert.Nil(t, results[1].Item)
+
+		var verr *ValidationError
+		assert.ErrorAs(t, results[2].Err, &verr)
+		assert.Equal(t, ItemID("4"), results[3].Item.ItemID)
+	}
+}
+
+func TestClient_AddBatch_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		started []string
+		peak    atomic.Int32
+	)
+	client := newAddBatchClient(t, &peak, func(url string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		started = append(started, url)
+		if len(started) == 2 {
+			cancel()
+		}
+	})
+
+	var urls []string
+	for i := 1; i <= 20; i++ {
+		urls = append(urls, fmt.Sprintf("https://example.com/%d", i))
+	}
+
+	results, err := client.AddBatch(ctx, addBatchInputs(urls...), 2)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, results, len(urls))
+
+	// Only requests already handed to a worker may have started.
+	assert.LessOrEqual(t, len(started), 4)
+	for _, result := range results[4:] {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+		assert.Nil(t, result.Item)
+	}
+}
+
+func TestClient_AddBatch_Empty(t *testing.T) {
+	var peak atomic.Int32
+	client := newAddBatchClient(t, &peak, nil)
+
+	results, err := client.AddBatch(context.Background(), nil, 4)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
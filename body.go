@@ -0,0 +1,93 @@
+package pocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// maxPooledBody keeps a single huge batch from pinning its buffer in the
+// pool forever.
+const maxPooledBody = 4 << 20
+
+// errBodyReleased is returned by GetBody once the request it belongs to has
+// finished and its buffer went back to the pool.
+var errBodyReleased = errors.New("request body already released")
+
+var bodyPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// requestBody is a JSON payload encoded into a pooled buffer. Readers handed
+// to the transport share the buffer, so it only goes back to the pool once
+// the request and every reader from GetBody have been closed.
+type requestBody struct {
+	buf  *bytes.Buffer
+	refs atomic.Int32
+}
+
+func newRequestBody(payload any) (*requestBody, error) {
+	buf := bodyPool.Get().(*bytes.Buffer)
+
+	if err := json.NewEncoder(buf).Encode(payload); err != nil {
+		buf.Reset()
+		bodyPool.Put(buf)
+		return nil, err
+	}
+	// Encode terminates the value with a newline json.Marshal would not add.
+	buf.Truncate(buf.Len() - 1)
+
+	b := &requestBody{buf: buf}
+	b.refs.Store(1)
+
+	return b, nil
+}
+
+func (b *requestBody) len() int64 {
+	return int64(b.buf.Len())
+}
+
+// reader returns a new reader over the payload, holding a reference until
+// it is closed. It fails with errBodyReleased after the last reference is
+// gone, since the buffer may already serve another request.
+func (b *requestBody) reader() (io.ReadCloser, error) {
+	for {
+		refs := b.refs.Load()
+		if refs <= 0 {
+			return nil, errBodyReleased
+		}
+		if b.refs.CompareAndSwap(refs, refs+1) {
+			break
+		}
+	}
+
+	return &bodyReader{Reader: bytes.NewReader(b.buf.Bytes()), owner: b}, nil
+}
+
+// release drops a reference and recycles the buffer after the last one.
+func (b *requestBody) release() {
+	if b.refs.Add(-1) != 0 {
+		return
+	}
+
+	if b.buf.Cap() <= maxPooledBody {
+		b.buf.Reset()
+		bodyPool.Put(b.buf)
+	}
+	b.buf = nil
+}
+
+type bodyReader struct {
+	*bytes.Reader
+	owner *requestBody
+	once  sync.Once
+}
+
+func (r *bodyReader) Close() error {
+	r.once.Do(r.owner.release)
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package pocket
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Actions builds a batch for Send. Invalid actions are not added; their
+// errors are collected and reported by Build.
+//
+//	actions, err := pocket.NewActions().
+//		Archive(id1).
+//		Favorite(id2).
+//		TagsAdd(id3, "go", "read").
+//		Build()
+type Actions struct {
+	actions []Action
+	errs    []error
+	n       int
+}
+
+func NewActions() *Actions {
+	return &Actions{}
+}
+
+func (b *Actions) Archive(itemID ItemID) *Actions {
+	return b.add(newItemAction(ActionArchive, itemID))
+}
+
+func (b *Actions) Readd(itemID ItemID) *Actions {
+	return b.add(newItemAction(ActionReadd, itemID))
+}
+
+func (b *Actions) Favorite(itemID ItemID) *Actions {
+	return b.add(newItemAction(ActionFavorite, itemID))
+}
+
+func (b *Actions) Unfavorite(itemID ItemID) *Actions {
+	return b.add(newItemAction(ActionUnfavorite, itemID))
+}
+
+func (b *Actions) Delete(itemID ItemID) *Actions {
+	return b.add(newItemAction(ActionDelete, itemID))
+}
+
+func (b *Actions) TagsAdd(itemID ItemID, tags ...string) *Actions {
+	return b.add(newTagsAction(ActionTagsAdd, itemID, tags))
+}
+
+func (b *Actions) TagsRemove(itemID ItemID, tags ...string) *Actions {
+	return b.add(newTagsAction(ActionTagsRemove, itemID, tags))
+}
+
+func (b *Actions) TagsReplace(itemID ItemID, tags ...string) *Actions {
+	return b.add(newTagsAction(ActionTagsReplace, itemID, tags))
+}
+
+func (b *Actions) TagsClear(itemID ItemID) *Actions {
+	return b.add(newItemAction(ActionTagsClear, itemID))
+}
+
+func (b *Actions) TagRename(oldTag, newTag string) *Actions {
+	return b.add(newTagRenameAction(oldTag, newTag))
+}
+
+func (b *Actions) TagDelete(tag string) *Actions {
+	return b.add(newTagDeleteAction(tag))
+}
+
+func (b *Actions) add(action Action, err error) *Actions {
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("action %d (%s): %w", b.n, action.Action, err))
+	} else {
+		b.actions = append(b.actions, action)
+	}
+	b.n++
+
+	return b
+}
+
+// Build returns the batch, or every validation error joined when any action
+// was invalid.
+func (b *Actions) Build() ([]Action, error) {
+	if len(b.errs) > 0 {
+		return nil, errors.Join(b.errs...)
+	}
+
+	if len(b.actions) == 0 {
+		return nil, &ValidationError{Field: "actions", Reason: "is empty"}
+	}
+
+	return append([]Action(nil), b.actions...), nil
+}
+
+// The constructors below validate and normalize a single action. They are
+// shared by the builder and the Client action methods so both encode
+// actions the same way. The returned Action always carries its type, even
+// with an error, for error messages.
+
+func newAddAction(input AddInput) (Action, error) {
+	u, err := input.cleanURL()
+	if err != nil {
+		return Action{Action: ActionAdd}, err
+	}
+
+	title, err := cleanTitle(input.Title, input.truncateTitle)
+	if err != nil {
+		return Action{Action: ActionAdd}, err
+	}
+
+	tags, err := sanitizeTags(input.Tags)
+	if err != nil {
+		return Action{Action: ActionAdd}, err
+	}
+
+	return Action{
+		Action: ActionAdd,
+		URL:    u,
+		Title:  title,
+		Tags:   tags,
+		RefID:  input.RefID,
+		Time:   input.Time,
+	}, nil
+}
+
+func newItemAction(action ActionType, itemID ItemID) (Action, error) {
+	if err := validateItemID(itemID); err != nil {
+		return Action{Action: action}, err
+	}
+
+	return Action{Action: action, ItemID: itemID}, nil
+}
+
+func newTagsAction(action ActionType, itemID ItemID, tags []string) (Action, error) {
+	if err := validateItemID(itemID); err != nil {
+		return Action{Action: action}, err
+	}
+
+	tags, err := sanitizeTags(tags)
+	if err != nil {
+		return Action{Action: action}, err
+	}
+
+	if len(tags) == 0 {
+		reason := "is empty"
+		if action == ActionTagsReplace {
+			reason += "; use TagsClear to remove all tags"
+		}
+		return Action{Action: action}, &ValidationError{Field: "Tags", Reason: reason}
+	}
+
+	return Action{Action: action, ItemID: itemID, Tags: tags}, nil
+}
+
+func newTagRenameAction(oldTag, newTag string) (Action, error) {
+	oldTag, err := validateTag("oldTag", oldTag)
+	if err != nil {
+		return Action{Action: ActionTagRename}, err
+	}
+
+	newTag, err = validateTag("newTag", newTag)
+	if err != nil {
+		return Action{Action: ActionTagRename}, err
+	}
+
+	return Action{Action: ActionTagRename, OldTag: oldTag, NewTag: newTag}, nil
+}
+
+func newTagDeleteAction(tag string) (Action, error) {
+	tag, err := validateTag("tag", tag)
+	if err != nil {
+		return Action{Action: ActionTagDelete}, err
+	}
+
+	return Action{Action: ActionTagDelete, Tag: tag}, nil
+}
+
+func validateItemID(itemID ItemID) error {
+	if itemID == "" {
+		return &ValidationError{Field: "itemID", Reason: "is empty"}
+	}
+
+	if strings.Trim(string(itemID), "0123456789") != "" {
+		return &ValidationError{Field: "itemID", Reason: fmt.Sprintf("%q is not numeric", itemID)}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package pocket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActions_Build(t *testing.T) {
+	got, err := NewActions().
+		Archive("1").
+		Readd("2").
+		Favorite("3").
+		Unfavorite("4").
+		Delete("5").
+		TagsAdd("6", " go", "Go", "read").
+		TagsRemove("7", "old").
+		TagsReplace("8", "new").
+		TagsClear("9").
+		TagRename("golang", " go ").
+		TagDelete("obsolete").
+		Build()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Action{
+		{Action: ActionArchive, ItemID: "1"},
+		{Action: ActionReadd, ItemID: "2"},
+		{Action: ActionFavorite, ItemID: "3"},
+		{Action: ActionUnfavorite, ItemID: "4"},
+		{Action: ActionDelete, ItemID: "5"},
+		{Action: ActionTagsAdd, ItemID: "6", Tags: []string{"go", "read"}},
+		{Action: ActionTagsRemove, ItemID: "7", Tags: []string{"old"}},
+		{Action: ActionTagsReplace, ItemID: "8", Tags: []string{"new"}},
+		{Action: ActionTagsClear, ItemID: "9"},
+		{Action: ActionTagRename, OldTag: "golang", NewTag: "go"},
+		{Action: ActionTagDelete, Tag: "obsolete"},
+	}, got)
+}
+
+func TestActions_Build_Errors(t *testing.T) {
+	got, err := NewActions().
+		Archive("1").
+		Favorite("x").
+		TagsReplace("3").
+		TagRename("a", "").
+		Build()
+
+	assert.Nil(t, got)
+	assert.EqualError(t, err, `action 1 (favorite): invalid itemID: "x" is not numeric
+action 2 (tags_replace): invalid Tags: is empty; use TagsClear to remove all tags
+action 3 (tag_rename): invalid newTag: is empty`)
+
+	var verr *ValidationError
+	assert.ErrorAs(t, err, &verr)
+}
+
+func TestActions_Build_Empty(t *testing.T) {
+	_, err := NewActions().Build()
+
+	var verr *ValidationError
+	assert.ErrorAs(t, err, &verr)
+}
+
+func TestActions_Build_Copy(t *testing.T) {
+	b := NewActions().Archive("1")
+
+	first, err := b.Build()
+	assert.NoError(t, err)
+
+	first[0].ItemID = "changed"
+	second, err := b.Archive("2").Build()
+	assert.NoError(t, err)
+	assert.Equal(t, []Action{{Action: ActionArchive, ItemID: "1"}, {Action: ActionArchive, ItemID: "2"}}, second)
+}
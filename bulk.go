@@ -0,0 +1,290 @@
+package pocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ArchiveAll archives every unread item matching filter, and the predicate
+// of WithPredicate if given, and returns how many were archived.
+// filter.State is forced to unread and filter.AccessToken to accessToken.
+// Each page is archived before the next one is fetched, so work done before
+// an error or cancellation is still counted; actions Pocket rejects are
+// reported as a *BatchError once every page is done.
+func (c *Client) ArchiveAll(ctx context.Context, accessToken string, filter GetInput, opts ...ActionOption) (int, error) {
+	filter.AccessToken = accessToken
+	filter.State = StateUnread
+
+	return c.bulkAction(ctx, filter, 0, opts, func(item Item) []Action {
+		return []Action{{Action: ActionArchive, ItemID: item.ItemID}}
+	})
+}
+
+// ErrNotConfirmed is returned by DeleteAll when confirm declines.
+var ErrNotConfirmed = errors.New("deletion not confirmed")
+
+// ErrMoreThanConfirmed is returned by DeleteAll when more items match than
+// confirm was asked about, such as items saved in the meantime.
+var ErrMoreThanConfirmed = errors.New("more items match than were confirmed")
+
+// DeleteAll permanently deletes every item matching filter, and the
+// predicate of WithPredicate if given. The matches are counted first and
+// confirm is asked with that number; nothing is deleted unless it returns
+// true. confirm is required, and filter.Offset must be zero since the count
+// covers the whole filter.
+//
+// No more items than were confirmed are deleted. Matches are retrieved
+// oldest first unless filter.Sort is set, so items saved after the count
+// come last; once the confirmed number is reached DeleteAll stops with
+// ErrMoreThanConfirmed if anything else still matches. Deleted items are
+// counted even when an error cuts the run short, and rejected deletions are
+// reported as a *BatchError.
+func (c *Client) DeleteAll(ctx context.Context, accessToken string, filter GetInput, confirm func(int) bool, opts ...ActionOption) (int, error) {
+	if confirm == nil {
+		return 0, &ValidationError{Field: "confirm", Reason: "is nil"}
+	}
+	if filter.Offset != 0 {
+		return 0, &ValidationError{Field: "Offset", Reason: "is not supported by DeleteAll"}
+	}
+
+	filter.AccessToken = accessToken
+	if filter.Sort == "" {
+		filter.Sort = SortOldest
+	}
+
+	n, err := c.countMatches(ctx, filter, applyActionOptions(opts).predicate)
+	if err != nil {
+		return 0, err
+	}
+
+	if n == 0 {
+		return 0, nil
+	}
+
+	if !confirm(n) {
+		return 0, ErrNotConfirmed
+	}
+
+	return c.bulkAction(ctx, filter, n, opts, func(item Item) []Action {
+		return []Action{{Action: ActionDelete, ItemID: item.ItemID}}
+	})
+}
+
+// FavoriteByTag favorites every item tagged tag and returns how many were
+// changed. Items that already are favorites are left alone.
+func (c *Client) FavoriteByTag(ctx context.Context, accessToken, tag string, opts ...ActionOption) (int, error) {
+	return c.favoriteByTag(ctx, accessToken, tag, ActionFavorite, FavoriteExclude, opts)
+}
+
+// UnfavoriteByTag is the inverse of FavoriteByTag.
+func (c *Client) UnfavoriteByTag(ctx context.Context, accessToken, tag string, opts ...ActionOption) (int, error) {
+	return c.favoriteByTag(ctx, accessToken, tag, ActionUnfavorite, FavoriteOnly, opts)
+}
+
+// favoriteByTag only retrieves the items the action changes, which also
+// takes them out of the filter once done.
+func (c *Client) favoriteByTag(ctx context.Context, accessToken, tag string, action ActionType, favorite FavoriteFilter, opts []ActionOption) (int, error) {
+	tag, err := validateTag("tag", tag)
+	if err != nil {
+		return 0, err
+	}
+
+	filter := GetInput{
+		AccessToken: accessToken,
+		State:       StateAll,
+		Tag:         tag,
+		Favorite:    favorite,
+	}
+
+	return c.bulkAction(ctx, filter, 0, opts, func(item Item) []Action {
+		return []Action{{Action: action, ItemID: item.ItemID}}
+	})
+}
+
+func failed(result ActionResult) bool {
+	return !result.OK
+}
+
+// countMatches counts the items matching filter and pred. Without pred the
+// server counts them.
+func (c *Client) countMatches(ctx context.Context, filter GetInput, pred Predicate) (int, error) {
+	if pred == nil {
+		return c.Count(ctx, filter)
+	}
+
+	filter = bulkFilter(filter, pred)
+
+	n := 0
+	for item, err := range c.Items(ctx, filter) {
+		if err != nil {
+			return 0, err
+		}
+		if pred(item) {
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// bulkFilter fills in the page size and detail the bulk helpers retrieve
+// with.
+func bulkFilter(filter GetInput, pred Predicate) GetInput {
+	if filter.Count == 0 {
+		filter.Count = maxCount
+	}
+	if filter.Detail == "" {
+		filter.Detail = DetailSimple
+		if pred != nil {
+			filter.Detail = DetailComplete
+		}
+	}
+
+	return filter
+}
+
+// bulkAction sends the actions built for every item matching filter and the
+// predicate of opts, one page at a time. An item is done once all of its
+// actions succeed, which takes it out of the filter, and the items behind it
+// move up, so the offset only advances past items that are still listed:
+// those the predicate rejects, that need no action, with a failed action, or
+// that a dry run left alone. A positive limit caps the number of items; matches beyond it end
+// the run with ErrMoreThanConfirmed. The count of done items is returned
+// even with an error.
+func (c *Client) bulkAction(ctx context.Context, filter GetInput, limit int, opts []ActionOption, build func(Item) []Action) (int, error) {
+	pred := applyActionOptions(opts).predicate
+	filter = bulkFilter(filter, pred)
+
+	filter.AccessToken = c.token(filter.AccessToken)
+	if err := filter.validate(); err != nil {
+		return 0, err
+	}
+
+	var (
+		done     int
+		matched  int
+		exceeded bool
+		sent     []Action
+		results  = &SendResponse{Status: 1}
+		handled  = make(map[ItemID]bool)
+	)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return done, err
+		}
+
+		page, err := c.Get(ctx, filter)
+		if err != nil {
+			return done, err
+		}
+
+		var (
+			items []Item
+			fresh int
+		)
+		for _, item := range page.Items {
+			if handled[item.ItemID] {
+				continue
+			}
+			handled[item.ItemID] = true
+			fresh++
+
+			if pred == nil || pred(item) {
+				items = append(items, item)
+			}
+		}
+
+		if limit > 0 && matched+len(items) > limit {
+			items = items[:limit-matched]
+			exceeded = true
+		}
+		matched += len(items)
+
+		// groups[i] is the number of actions of items[i]. Items needing none
+		// stay listed like those the predicate rejects.
+		var actions []Action
+		groups := make([]int, len(items))
+		stay := len(page.Items) - len(items)
+		for i, item := range items {
+			built := build(item)
+			groups[i] = len(built)
+			actions = append(actions, built...)
+			if len(built) == 0 {
+				stay++
+			}
+		}
+
+		if len(actions) > 0 {
+			resp, err := c.sendChunked(ctx, filter.AccessToken, actions, opts)
+
+			var berr *BatchError
+			if err != nil && !errors.As(err, &berr) {
+				return done, err
+			}
+
+			if resp.DryRun {
+				// Nothing changed, so the items stay listed.
+				for _, n := range groups {
+					if n > 0 {
+						done++
+						stay++
+					}
+				}
+			} else {
+				if resp.Status != 1 {
+					results.Status = resp.Status
+				}
+				for _, result := range resp.Results {
+					result.Index += len(sent)
+					results.Results = append(results.Results, result)
+				}
+
+				// sendChunked returns a result for every action.
+				next := 0
+				for _, n := range groups {
+					if n == 0 {
+						continue
+					}
+					if slices.ContainsFunc(resp.Results[next:next+n], failed) {
+						stay++
+					} else {
+						done++
+					}
+					next += n
+				}
+				sent = append(sent, actions...)
+			}
+		}
+
+		if fresh == 0 && len(page.Items) == filter.Count {
+			// A full page of items already handled means the server is
+			// ignoring the offset; bail out instead of looping forever.
+			return done, errors.New("Server returned the same page twice")
+		}
+
+		if exceeded || len(page.Items) < filter.Count {
+			break
+		}
+
+		filter.Offset += stay
+	}
+
+	var err error
+	if exceeded {
+		err = fmt.Errorf("%w, stopped after %d", ErrMoreThanConfirmed, limit)
+	}
+
+	if len(sent) > 0 {
+		if berr := batchError(sent, results); berr != nil {
+			if err != nil {
+				return done, errors.Join(berr, err)
+			}
+			return done, berr
+		}
+	}
+
+	return done, err
+}
@@ -0,0 +1,529 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAccount is an in-memory Pocket list serving retrieve and send
+// requests, so bulk helpers can be run against a list that changes under
+// them. Actions on items for which reject returns true fail, as do
+// tag_rename actions whose old tag it rejects. With plainAdds, add actions
+// are acknowledged with a plain true instead of the new item.
+type fakeAccount struct {
+	mu        sync.Mutex
+	items     []Item
+	reject    func(id string) bool
+	onSend    func()
+	plainAdds bool
+	gets      int
+	sends     int
+	actioned  []string
+}
+
+func newFakeAccount(n int) *fakeAccount {
+	a := &fakeAccount{reject: func(string) bool { return false }}
+	for i := 1; i <= n; i++ {
+		a.items = append(a.items, Item{ItemID: ItemID(strconv.Itoa(i)), Status: ItemStatusUnread})
+	}
+
+	return a
+}
+
+func (a *fakeAccount) client(t *testing.T) *Client {
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+
+		var body string
+		switch r.URL.Path {
+		case "/v3/get":
+			body = a.get(t, readBody(t, r))
+		case "/v3/send":
+			body = a.send(t, readBody(t, r))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+
+	return &Client{client: &http.Client{Transport: transport}, consumerKey: "key"}
+}
+
+func (a *fakeAccount) get(t *testing.T, body string) string {
+	a.gets++
+
+	var req getRequest
+	assert.NoError(t, json.Unmarshal([]byte(body), &req))
+
+	var matching []Item
+	for _, item := range a.items {
+		switch {
+		case req.State == StateUnread && !item.IsUnread(),
+			req.State == StateArchive && !item.IsArchived(),
+			req.Tag != "" && !slices.Contains(item.Tags, req.Tag),
+			req.Favorite != "" && req.Favorite != strconv.Itoa(item.Favorite),
+			item.IsDeleted():
+			continue
+		}
+		matching = append(matching, item)
+	}
+
+	total := len(matching)
+	matching = matching[min(req.Offset, len(matching)):]
+	matching = matching[:min(req.Count, len(matching))]
+
+	raw := make([]string, 0, len(matching))
+	for i, item := range matching {
+		tags := make([]string, 0, len(item.Tags))
+		for _, tag := range item.Tags {
+			tags = append(tags, fmt.Sprintf(`%q:{"item_id":%q,"tag":%q}`, tag, item.ItemID, tag))
+		}
+		raw = append(raw, fmt.Sprintf(`{"item_id":%q,"given_url":%q,"sort_id":%d,"status":%q,"favorite":"%d","time_added":"%d","tags":{%s}}`,
+			item.ItemID, item.GivenURL, i, item.Status, item.Favorite, unixSeconds(item.TimeAdded), strings.Join(tags, ",")))
+	}
+
+	page := rawPage(raw...)
+	if req.Total == "1" {
+		page = strings.TrimSuffix(page, "}") + fmt.Sprintf(`,"total":"%d"}`, total)
+	}
+
+	return page
+}
+
+func (a *fakeAccount) send(t *testing.T, body string) string {
+	a.sends++
+	if a.onSend != nil {
+		a.onSend()
+	}
+
+	var req struct {
+		Actions []sendAction `json:"actions"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(body), &req))
+
+	results := make([]string, 0, len(req.Actions))
+	for _, action := range req.Actions {
+		if action.Action == ActionTagRename {
+			if a.reject(action.OldTag) {
+				results = append(results, "false")
+				continue
+			}
+			for i := range a.items {
+				if j := slices.Index(a.items[i].Tags, action.OldTag); j >= 0 {
+					a.items[i].Tags[j] = action.NewTag
+				}
+			}
+			a.actioned = append(a.actioned, action.OldTag)
+			results = append(results, "true")
+			continue
+		}
+
+		if action.Action == ActionAdd {
+			item := Item{ItemID: ItemID(strconv.Itoa(len(a.items) + 1)), GivenURL: action.URL, Status: ItemStatusUnread}
+			if action.Tags != "" {
+				item.Tags = strings.Split(action.Tags, ",")
+			}
+			a.items = append(a.items, item)
+			a.actioned = append(a.actioned, action.URL)
+			if a.plainAdds {
+				results = append(results, "true")
+			} else {
+				results = append(results, fmt.Sprintf(`{"item_id":%q,"given_url":%q}`, item.ItemID, action.URL))
+			}
+			continue
+		}
+
+		i := slices.IndexFunc(a.items, func(item Item) bool { return item.ItemID == action.ItemID })
+		if i < 0 || a.reject(string(action.ItemID)) {
+			results = append(results, "false")
+			continue
+		}
+
+		switch action.Action {
+		case ActionArchive:
+			a.items[i].Status = ItemStatusArchived
+		case ActionReadd:
+			a.items[i].Status = ItemStatusUnread
+		case ActionDelete:
+			a.items[i].Status = ItemStatusDeleted
+		case ActionFavorite:
+			a.items[i].Favorite = 1
+		case ActionUnfavorite:
+			a.items[i].Favorite = 0
+		case ActionTagsReplace:
+			a.items[i].Tags = strings.Split(action.Tags, ",")
+		case ActionTagsClear:
+			a.items[i].Tags = nil
+		case ActionTagsAdd:
+			for _, tag := range strings.Split(action.Tags, ",") {
+				if !slices.Contains(a.items[i].Tags, tag) {
+					a.items[i].Tags = append(a.items[i].Tags, tag)
+				}
+			}
+		case ActionTagsRemove:
+			removes := strings.Split(action.Tags, ",")
+			a.items[i].Tags = slices.DeleteFunc(a.items[i].Tags, func(tag string) bool { return slices.Contains(removes, tag) })
+		}
+		a.actioned = append(a.actioned, string(action.ItemID))
+		results = append(results, "true")
+	}
+
+	return `{"status":1,"action_results":[` + strings.Join(results, ",") + `]}`
+}
+
+func (a *fakeAccount) unread() []string {
+	var ids []string
+	for _, item := range a.items {
+		if item.IsUnread() {
+			ids = append(ids, string(item.ItemID))
+		}
+	}
+
+	return ids
+}
+
+func TestClient_ArchiveAll(t *testing.T) {
+	account := newFakeAccount(70)
+
+	got, err := account.client(t).ArchiveAll(context.Background(), "access-to-ken", GetInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, 70, got)
+	assert.Empty(t, account.unread())
+	assert.Len(t, account.actioned, 70)
+	assert.Equal(t, 3, account.sends)
+}
+
+func TestClient_ArchiveAll_PartialFailure(t *testing.T) {
+	account := newFakeAccount(70)
+	account.reject = func(id string) bool { return id == "5" || id == "40" || id == "70" }
+
+	got, err := account.client(t).ArchiveAll(context.Background(), "access-to-ken", GetInput{})
+	assert.Equal(t, 67, got)
+	assert.Equal(t, []string{"5", "40", "70"}, account.unread())
+
+	var berr *BatchError
+	if assert.ErrorAs(t, err, &berr) {
+		var failed []string
+		for _, action := range berr.Actions {
+			failed = append(failed, string(action.ItemID))
+		}
+		assert.Equal(t, []string{"5", "40", "70"}, failed)
+		assert.Len(t, berr.Response.Results, 70)
+	}
+}
+
+func TestClient_ArchiveAll_Filter(t *testing.T) {
+	account := newFakeAccount(40)
+	for i := range account.items {
+		if i%4 == 0 {
+			account.items[i].Tags = []string{"old"}
+		}
+	}
+
+	got, err := account.client(t).ArchiveAll(context.Background(), "access-to-ken", GetInput{Tag: "old", State: StateAll})
+	assert.NoError(t, err)
+	assert.Equal(t, 10, got)
+	assert.Len(t, account.unread(), 30)
+}
+
+func TestClient_ArchiveAll_Predicate(t *testing.T) {
+	now := time.Now()
+	yearAgo := now.AddDate(-1, 0, 0)
+
+	// Old and unfavorited items are spread over three pages, between items
+	// the predicate rejects, which must be skipped rather than re-read.
+	account := newFakeAccount(80)
+	var want []string
+	for i := range account.items {
+		account.items[i].TimeAdded = now.AddDate(0, 0, -i*10)
+		if i%7 == 0 {
+			account.items[i].Favorite = 1
+		}
+		if account.items[i].TimeAdded.Before(yearAgo) && i%7 != 0 {
+			want = append(want, string(account.items[i].ItemID))
+		}
+	}
+
+	notFavorite := func(i Item) bool { return i.Favorite == 0 }
+	got, err := account.client(t).ArchiveAll(context.Background(), "access-to-ken", GetInput{}, WithPredicate(And(OlderThan(yearAgo), notFavorite)))
+	assert.NoError(t, err)
+	assert.Equal(t, len(want), got)
+	assert.Equal(t, want, account.actioned)
+}
+
+func TestClient_ArchiveAll_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	account := newFakeAccount(100)
+	account.onSend = cancel
+
+	got, err := account.client(t).ArchiveAll(ctx, "access-to-ken", GetInput{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 30, got)
+	assert.Len(t, account.unread(), 70)
+	assert.Equal(t, 1, account.sends)
+}
+
+func TestClient_ArchiveAll_Invalid(t *testing.T) {
+	account := newFakeAccount(1)
+
+	_, err := account.client(t).ArchiveAll(context.Background(), "", GetInput{})
+	var verr *ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Zero(t, account.gets)
+}
+
+func TestClient_DeleteAll(t *testing.T) {
+	account := newFakeAccount(45)
+	for i := range account.items[:40] {
+		account.items[i].Status = ItemStatusArchived
+	}
+
+	var asked []int
+	got, err := account.client(t).DeleteAll(context.Background(), "access-to-ken", GetInput{State: StateArchive}, func(n int) bool {
+		asked = append(asked, n)
+		return true
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 40, got)
+	assert.Equal(t, []int{40}, asked)
+	assert.Equal(t, []string{"41", "42", "43", "44", "45"}, account.unread())
+	assert.Len(t, account.actioned, 40)
+}
+
+func TestClient_DeleteAll_Predicate(t *testing.T) {
+	account := newFakeAccount(70)
+	for i := range account.items {
+		if i%2 == 0 {
+			account.items[i].Tags = []string{"keep"}
+		}
+	}
+
+	got, err := account.client(t).DeleteAll(context.Background(), "access-to-ken", GetInput{}, func(n int) bool {
+		assert.Equal(t, 35, n)
+		return true
+	}, WithPredicate(Not(HasTag("keep"))))
+	assert.NoError(t, err)
+	assert.Equal(t, 35, got)
+	assert.Len(t, account.unread(), 35)
+	for _, item := range account.items {
+		assert.Equal(t, item.IsDeleted(), len(item.Tags) == 0, item.ItemID)
+	}
+}
+
+func TestClient_DeleteAll_MoreThanConfirmed(t *testing.T) {
+	account := newFakeAccount(40)
+
+	// Items saved between the count and the run land on the second page.
+	got, err := account.client(t).DeleteAll(context.Background(), "access-to-ken", GetInput{}, func(n int) bool {
+		assert.Equal(t, 40, n)
+		for i := 41; i <= 75; i++ {
+			account.items = append(account.items, Item{ItemID: ItemID(strconv.Itoa(i)), Status: ItemStatusUnread})
+		}
+		return true
+	})
+	assert.ErrorIs(t, err, ErrMoreThanConfirmed)
+	assert.Equal(t, 40, got)
+	assert.Len(t, account.actioned, 40)
+	assert.Len(t, account.unread(), 35)
+	for _, item := range account.items[:40] {
+		assert.True(t, item.IsDeleted(), item.ItemID)
+	}
+}
+
+func TestClient_DeleteAll_Declined(t *testing.T) {
+	account := newFakeAccount(10)
+
+	got, err := account.client(t).DeleteAll(context.Background(), "access-to-ken", GetInput{}, func(n int) bool {
+		assert.Equal(t, 10, n)
+		return false
+	})
+	assert.ErrorIs(t, err, ErrNotConfirmed)
+	assert.Zero(t, got)
+	assert.Zero(t, account.sends)
+	assert.Len(t, account.unread(), 10)
+}
+
+func TestClient_DeleteAll_NothingToDelete(t *testing.T) {
+	account := newFakeAccount(0)
+
+	got, err := account.client(t).DeleteAll(context.Background(), "access-to-ken", GetInput{}, func(int) bool {
+		t.Error("confirm called without matches")
+		return true
+	})
+	assert.NoError(t, err)
+	assert.Zero(t, got)
+}
+
+func TestClient_DeleteAll_NilConfirm(t *testing.T) {
+	account := newFakeAccount(10)
+
+	_, err := account.client(t).DeleteAll(context.Background(), "access-to-ken", GetInput{}, nil)
+	var verr *ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Zero(t, account.gets)
+}
+
+func TestClient_DeleteAll_Offset(t *testing.T) {
+	account := newFakeAccount(10)
+	confirmed := false
+
+	_, err := account.client(t).DeleteAll(context.Background(), "access-to-ken", GetInput{Offset: 5}, func(int) bool {
+		confirmed = true
+		return true
+	})
+	var verr *ValidationError
+	if assert.ErrorAs(t, err, &verr) {
+		assert.Equal(t, "Offset", verr.Field)
+	}
+	assert.False(t, confirmed)
+	assert.Zero(t, account.gets)
+	assert.Len(t, account.items, 10)
+}
+
+func TestClient_DeleteAll_MidBatchFailure(t *testing.T) {
+	account := newFakeAccount(65)
+	account.reject = func(id string) bool { return id == "31" || id == "32" }
+
+	got, err := account.client(t).DeleteAll(context.Background(), "access-to-ken", GetInput{}, func(int) bool { return true })
+	assert.Equal(t, 63, got)
+	assert.Equal(t, []string{"31", "32"}, account.unread())
+
+	var berr *BatchError
+	if assert.ErrorAs(t, err, &berr) {
+		assert.Equal(t, []int{30, 31}, berr.Failed)
+		assert.Equal(t, []Action{{Action: ActionDelete, ItemID: "31"}, {Action: ActionDelete, ItemID: "32"}}, berr.Actions)
+	}
+}
+
+func (a *fakeAccount) favorites() []string {
+	var ids []string
+	for _, item := range a.items {
+		if item.Favorite == 1 {
+			ids = append(ids, string(item.ItemID))
+		}
+	}
+
+	return ids
+}
+
+func TestClient_FavoriteByTag(t *testing.T) {
+	account := newFakeAccount(80)
+	var tagged []string
+	for i := range account.items {
+		if i%2 == 0 {
+			account.items[i].Tags = []string{"talk-reference"}
+			tagged = append(tagged, string(account.items[i].ItemID))
+		}
+		if i%10 == 0 {
+			account.items[i].Favorite = 1
+		}
+		if i%3 == 0 {
+			account.items[i].Status = ItemStatusArchived
+		}
+	}
+	client := account.client(t)
+
+	got, err := client.FavoriteByTag(context.Background(), "access-to-ken", "talk-reference")
+	assert.NoError(t, err)
+	assert.Equal(t, 32, got)
+	assert.Equal(t, tagged, account.favorites())
+
+	got, err = client.UnfavoriteByTag(context.Background(), "access-to-ken", " talk-reference ")
+	assert.NoError(t, err)
+	assert.Equal(t, 40, got)
+	assert.Empty(t, account.favorites())
+}
+
+func TestClient_FavoriteByTag_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	account := newFakeAccount(60)
+	for i := range account.items {
+		account.items[i].Tags = []string{"go"}
+	}
+	account.onSend = cancel
+
+	got, err := account.client(t).FavoriteByTag(ctx, "access-to-ken", "go")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 30, got)
+	assert.Len(t, account.favorites(), 30)
+}
+
+func TestClient_FavoriteByTag_InvalidTag(t *testing.T) {
+	account := newFakeAccount(1)
+
+	for _, tag := range []string{"", " ", "a,b"} {
+		_, err := account.client(t).FavoriteByTag(context.Background(), "access-to-ken", tag)
+		var verr *ValidationError
+		assert.ErrorAs(t, err, &verr)
+	}
+	assert.Zero(t, account.gets)
+}
+
+func TestClient_BulkDryRun(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(c *Client, opts ...ActionOption) (int, error)
+		want int
+	}{
+		{
+			name: "ArchiveAll",
+			run: func(c *Client, opts ...ActionOption) (int, error) {
+				return c.ArchiveAll(context.Background(), "access-to-ken", GetInput{}, opts...)
+			},
+			want: 75,
+		},
+		{
+			name: "DeleteAll",
+			run: func(c *Client, opts ...ActionOption) (int, error) {
+				return c.DeleteAll(context.Background(), "access-to-ken", GetInput{}, func(int) bool { return true }, opts...)
+			},
+			want: 75,
+		},
+		{
+			name: "FavoriteByTag",
+			run: func(c *Client, opts ...ActionOption) (int, error) {
+				return c.FavoriteByTag(context.Background(), "access-to-ken", "go", opts...)
+			},
+			want: 25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, dry := range []func(c *Client) (*Client, []ActionOption){
+				func(c *Client) (*Client, []ActionOption) { return c, []ActionOption{WithDryRun()} },
+				func(c *Client) (*Client, []ActionOption) { return c.DryRun(), nil },
+			} {
+				account := newFakeAccount(75)
+				for i := range account.items[:25] {
+					account.items[i].Tags = []string{"go"}
+				}
+
+				client, opts := dry(account.client(t))
+				got, err := tt.run(client, opts...)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				assert.Zero(t, account.sends)
+				assert.Len(t, account.unread(), 75)
+				assert.Empty(t, account.favorites())
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package pocket
+
+import (
+	"context"
+	"time"
+)
+
+// GetOption narrows down the convenience getters such as GetArchive.
+type GetOption func(*GetInput)
+
+func WithState(s State) GetOption {
+	return func(i *GetInput) { i.State = s }
+}
+
+func WithFavorite(f FavoriteFilter) GetOption {
+	return func(i *GetInput) { i.Favorite = f }
+}
+
+func WithTag(tag string) GetOption {
+	return func(i *GetInput) { i.Tag = tag }
+}
+
+func WithContentType(t ContentType) GetOption {
+	return func(i *GetInput) { i.ContentType = t }
+}
+
+func WithSort(s Sort) GetOption {
+	return func(i *GetInput) { i.Sort = s }
+}
+
+func WithDetail(d Detail) GetOption {
+	return func(i *GetInput) { i.Detail = d }
+}
+
+func WithSearch(search string) GetOption {
+	return func(i *GetInput) { i.Search = search }
+}
+
+func WithDomain(domain string) GetOption {
+	return func(i *GetInput) { i.Domain = domain }
+}
+
+func WithSince(since time.Time) GetOption {
+	return func(i *GetInput) { i.Since = since }
+}
+
+// WithPageSize sets how many items are requested per page.
+func WithPageSize(count int) GetOption {
+	return func(i *GetInput) { i.Count = count }
+}
+
+// getAllWith applies opts on top of the getter's defaults in input, lets
+// fixed override whatever the getter must control, and pages through every
+// match.
+func (c *Client) getAllWith(ctx context.Context, input GetInput, opts []GetOption, fixed func(*GetInput)) ([]Item, error) {
+	for _, opt := range opts {
+		opt(&input)
+	}
+
+	if fixed != nil {
+		fixed(&input)
+	}
+
+	return c.GetAll(ctx, input)
+}
+
+// GetArchive returns every archived item.
+func (c *Client) GetArchive(ctx context.Context, accessToken string, opts ...GetOption) ([]Item, error) {
+	return c.getAllWith(ctx, GetInput{AccessToken: accessToken}, opts, func(i *GetInput) {
+		i.State = StateArchive
+	})
+}
+
+// GetByTag returns every item carrying tag, archived ones included unless
+// WithState says otherwise. Pass TagUntagged for items without any tag.
+func (c *Client) GetByTag(ctx context.Context, accessToken, tag string, opts ...GetOption) ([]Item, error) {
+	if tag == "" {
+		return nil, &ValidationError{Field: "Tag", Reason: "is empty"}
+	}
+
+	input := GetInput{AccessToken: accessToken, State: StateAll}
+
+	return c.getAllWith(ctx, input, opts, func(i *GetInput) {
+		i.Tag = tag
+	})
+}
+
+// GetRecent returns the n most recently saved items, archived ones included,
+// newest first. Requests are split into pages of at most 30 items and the
+// last one only asks for what is still missing.
+func (c *Client) GetRecent(ctx context.Context, accessToken string, n int) ([]Item, error) {
+	if n <= 0 {
+		return nil, &ValidationError{Field: "n", Reason: "must be positive"}
+	}
+
+	input := GetInput{
+		AccessToken: accessToken,
+		State:       StateAll,
+		Sort:        SortNewest,
+	}
+
+	items := make([]Item, 0, n)
+	for len(items) < n {
+		input.Count = min(n-len(items), maxCount)
+
+		resp, err := c.Get(ctx, input)
+		if err != nil {
+			return items, err
+		}
+
+		items = append(items, resp.Items...)
+		if len(resp.Items) < input.Count {
+			break
+		}
+
+		input.Offset += len(resp.Items)
+	}
+
+	if len(items) > n {
+		items = items[:n]
+	}
+
+	return items, nil
+}
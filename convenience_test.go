@@ -0,0 +1,210 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetArchive(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []GetOption
+		responses []string
+		want      []string
+		wantBody  string
+		wantErr   bool
+	}{
+		{
+			name:      "Default-OK",
+			responses: []string{page("1", "2")},
+			want:      []string{"1", "2"},
+			wantBody:  `{"consumer_key":"key","access_token":"access-to-ken","count":30,"state":"archive"}`,
+		},
+		{
+			name:      "Options applied",
+			opts:      []GetOption{WithTag("golang"), WithSort(SortOldest), WithPageSize(2)},
+			responses: []string{page("1", "2"), page("3")},
+			want:      []string{"1", "2", "3"},
+			wantBody:  `{"consumer_key":"key","access_token":"access-to-ken","count":2,"state":"archive","tag":"golang","sort":"oldest"}`,
+		},
+		{
+			name:    "Invalid option",
+			opts:    []GetOption{WithTag("a,b")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, bodies := newScriptedClient(t, "/v3/get", tt.responses...)
+
+			got, err := client.GetArchive(context.Background(), "access-to-ken", tt.opts...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, *bodies)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, itemIDs(got))
+			if assert.NotEmpty(t, *bodies) {
+				assert.JSONEq(t, tt.wantBody, (*bodies)[0])
+			}
+		})
+	}
+}
+
+func TestClient_GetByTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		tag       string
+		opts      []GetOption
+		responses []string
+		want      []string
+		wantBody  string
+		wantErr   bool
+	}{
+		{
+			name:      "Default-OK",
+			tag:       "golang",
+			responses: []string{page("1", "2")},
+			want:      []string{"1", "2"},
+			wantBody:  `{"consumer_key":"key","access_token":"access-to-ken","count":30,"state":"all","tag":"golang"}`,
+		},
+		{
+			name:      "Untagged",
+			tag:       TagUntagged,
+			responses: []string{page("1")},
+			want:      []string{"1"},
+			wantBody:  `{"consumer_key":"key","access_token":"access-to-ken","count":30,"state":"all","tag":"_untagged_"}`,
+		},
+		{
+			name:      "Paginates with state option",
+			tag:       "golang",
+			opts:      []GetOption{WithState(StateUnread), WithPageSize(2), WithTag("ignored")},
+			responses: []string{page("1", "2"), page("3", "4"), page()},
+			want:      []string{"1", "2", "3", "4"},
+			wantBody:  `{"consumer_key":"key","access_token":"access-to-ken","count":2,"state":"unread","tag":"golang"}`,
+		},
+		{
+			name:    "Tag with comma",
+			tag:     "go,rust",
+			wantErr: true,
+		},
+		{
+			name:    "Empty tag",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, bodies := newScriptedClient(t, "/v3/get", tt.responses...)
+
+			got, err := client.GetByTag(context.Background(), "access-to-ken", tt.tag, tt.opts...)
+			if tt.wantErr {
+				var verr *ValidationError
+				if assert.ErrorAs(t, err, &verr) {
+					assert.Equal(t, "Tag", verr.Field)
+				}
+				assert.Empty(t, *bodies)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, itemIDs(got))
+			assert.Len(t, *bodies, len(tt.responses))
+			assert.JSONEq(t, tt.wantBody, (*bodies)[0])
+		})
+	}
+}
+
+// idRange returns the decimal IDs from first to last inclusive.
+func idRange(first, last int) []string {
+	ids := make([]string, 0, last-first+1)
+	for i := first; i <= last; i++ {
+		ids = append(ids, strconv.Itoa(i))
+	}
+
+	return ids
+}
+
+func TestClient_GetRecent(t *testing.T) {
+	tests := []struct {
+		name       string
+		n          int
+		responses  []string
+		want       []string
+		wantCounts []int
+		wantErr    bool
+	}{
+		{
+			name:       "Fewer than a page",
+			n:          5,
+			responses:  []string{page(idRange(1, 5)...)},
+			want:       idRange(1, 5),
+			wantCounts: []int{5},
+		},
+		{
+			name:       "Exactly a page",
+			n:          30,
+			responses:  []string{page(idRange(1, 30)...)},
+			want:       idRange(1, 30),
+			wantCounts: []int{30},
+		},
+		{
+			name: "Spans pages",
+			n:    75,
+			responses: []string{
+				page(idRange(1, 30)...),
+				page(idRange(31, 60)...),
+				page(idRange(61, 75)...),
+			},
+			want:       idRange(1, 75),
+			wantCounts: []int{30, 30, 15},
+		},
+		{
+			name:       "Account smaller than n",
+			n:          75,
+			responses:  []string{page(idRange(1, 30)...), page(idRange(31, 40)...)},
+			want:       idRange(1, 40),
+			wantCounts: []int{30, 30},
+		},
+		{
+			name:    "Non-positive n",
+			n:       0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, bodies := newScriptedClient(t, "/v3/get", tt.responses...)
+
+			got, err := client.GetRecent(context.Background(), "access-to-ken", tt.n)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, *bodies)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, itemIDs(got))
+
+			offset := 0
+			for i, count := range tt.wantCounts {
+				var req getRequest
+				assert.NoError(t, json.Unmarshal([]byte((*bodies)[i]), &req))
+				assert.Equal(t, count, req.Count)
+				assert.Equal(t, offset, req.Offset)
+				assert.Equal(t, SortNewest, req.Sort)
+				assert.Equal(t, StateAll, req.State)
+				offset += count
+			}
+		})
+	}
+}
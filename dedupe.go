@@ -0,0 +1,103 @@
+package pocket
+
+import (
+	"context"
+)
+
+// DuplicateGroup is a set of items that resolved to the same article.
+type DuplicateGroup struct {
+	ResolvedID string
+	// Kept is the oldest save, the one DeduplicateItems keeps.
+	Kept Item
+	// Duplicates are the later saves, candidates for deletion.
+	Duplicates []Item
+}
+
+// DeduplicateItems groups items by resolved_id, keeps the oldest save of
+// each group and reports the rest. Unresolved items (resolved_id "0" or
+// empty) are never treated as duplicates of each other. The kept items stay
+// in input order.
+func DeduplicateItems(items []Item) ([]Item, []DuplicateGroup) {
+	kept := make(map[string]int)
+	var groups []*DuplicateGroup
+	byID := make(map[string]*DuplicateGroup)
+
+	for i, item := range items {
+		id := item.ResolvedID
+		if id == "" || id == "0" {
+			continue
+		}
+
+		first, ok := kept[id]
+		if !ok {
+			kept[id] = i
+			continue
+		}
+
+		group, ok := byID[id]
+		if !ok {
+			group = &DuplicateGroup{ResolvedID: id}
+			byID[id] = group
+			groups = append(groups, group)
+		}
+
+		if savedBefore(item, items[first]) {
+			group.Duplicates = append(group.Duplicates, items[first])
+			kept[id] = i
+		} else {
+			group.Duplicates = append(group.Duplicates, item)
+		}
+	}
+
+	unique := make([]Item, 0, len(items))
+	for i, item := range items {
+		id := item.ResolvedID
+		if id == "" || id == "0" || kept[id] == i {
+			unique = append(unique, item)
+		}
+	}
+
+	result := make([]DuplicateGroup, 0, len(groups))
+	for _, group := range groups {
+		group.Kept = items[kept[group.ResolvedID]]
+		result = append(result, *group)
+	}
+
+	return unique, result
+}
+
+// savedBefore orders by time_added, falling back to the item ID, which Pocket
+// hands out in increasing order.
+func savedBefore(a, b Item) bool {
+	if !a.TimeAdded.Equal(b.TimeAdded) {
+		return a.TimeAdded.Before(b.TimeAdded)
+	}
+
+	if len(a.ItemID) != len(b.ItemID) {
+		return len(a.ItemID) < len(b.ItemID)
+	}
+
+	return a.ItemID < b.ItemID
+}
+
+// FindDuplicates retrieves the whole account and reports every group of items
+// sharing a resolved_id.
+func (c *Client) FindDuplicates(ctx context.Context, accessToken string) ([]DuplicateGroup, error) {
+	accessToken = c.token(accessToken)
+	if accessToken == "" {
+		return nil, errNoAccessToken()
+	}
+
+	items, err := c.GetAll(ctx, GetInput{
+		AccessToken: accessToken,
+		State:       StateAll,
+		Detail:      DetailSimple,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, groups := DeduplicateItems(items)
+
+	return groups, nil
+}
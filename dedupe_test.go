@@ -0,0 +1,85 @@
+package pocket
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicateItems(t *testing.T) {
+	at := func(sec int64) time.Time { return time.Unix(sec, 0).UTC() }
+
+	amp := Item{ItemID: "10", ResolvedID: "100", GivenURL: "https://example.com/amp/a", TimeAdded: at(300)}
+	orig := Item{ItemID: "11", ResolvedID: "100", GivenURL: "https://example.com/a", TimeAdded: at(100)}
+	utm := Item{ItemID: "12", ResolvedID: "100", GivenURL: "https://example.com/a?utm_source=x", TimeAdded: at(200)}
+	other := Item{ItemID: "20", ResolvedID: "200", TimeAdded: at(50)}
+	unresolvedA := Item{ItemID: "30", ResolvedID: "0", TimeAdded: at(10)}
+	unresolvedB := Item{ItemID: "31", ResolvedID: "0", TimeAdded: at(20)}
+	noResolved := Item{ItemID: "40"}
+	sameTimeA := Item{ItemID: "9", ResolvedID: "500", TimeAdded: at(1)}
+	sameTimeB := Item{ItemID: "50", ResolvedID: "500", TimeAdded: at(1)}
+
+	tests := []struct {
+		name       string
+		items      []Item
+		wantUnique []Item
+		wantGroups []DuplicateGroup
+	}{
+		{
+			name:       "Keeps oldest save",
+			items:      []Item{amp, other, orig, utm},
+			wantUnique: []Item{other, orig},
+			wantGroups: []DuplicateGroup{
+				{ResolvedID: "100", Kept: orig, Duplicates: []Item{amp, utm}},
+			},
+		},
+		{
+			name:       "Unresolved never grouped",
+			items:      []Item{unresolvedA, unresolvedB, noResolved, noResolved},
+			wantUnique: []Item{unresolvedA, unresolvedB, noResolved, noResolved},
+			wantGroups: []DuplicateGroup{},
+		},
+		{
+			name:       "Same time falls back to item ID",
+			items:      []Item{sameTimeB, sameTimeA},
+			wantUnique: []Item{sameTimeA},
+			wantGroups: []DuplicateGroup{
+				{ResolvedID: "500", Kept: sameTimeA, Duplicates: []Item{sameTimeB}},
+			},
+		},
+		{
+			name:       "Empty",
+			wantUnique: []Item{},
+			wantGroups: []DuplicateGroup{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unique, groups := DeduplicateItems(tt.items)
+			assert.Equal(t, tt.wantUnique, unique)
+			assert.Equal(t, tt.wantGroups, groups)
+		})
+	}
+}
+
+func TestClient_FindDuplicates(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/get", rawPage(
+		`{"item_id":"1","resolved_id":"100","time_added":"300","sort_id":0}`,
+		`{"item_id":"2","resolved_id":"100","time_added":"100","sort_id":1}`,
+		`{"item_id":"3","resolved_id":"0","time_added":"100","sort_id":2}`,
+		`{"item_id":"4","resolved_id":"0","time_added":"100","sort_id":3}`,
+	))
+
+	got, err := client.FindDuplicates(context.Background(), "access-to-ken")
+	assert.NoError(t, err)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, ItemID("2"), got[0].Kept.ItemID)
+		assert.Equal(t, []string{"1"}, itemIDs(got[0].Duplicates))
+	}
+	if assert.Len(t, *bodies, 1) {
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","count":30,"state":"all","detailType":"simple"}`, (*bodies)[0])
+	}
+}
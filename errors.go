@@ -0,0 +1,134 @@
+package pocket
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrInvalidToken matches the error of a call whose access token Pocket
+	// does not accept.
+	ErrInvalidToken = errors.New("invalid access token")
+	// ErrTokenRevoked matches the error of a call whose access token the
+	// user has disconnected from their Pocket account. Retrying does not
+	// help; the user has to authorize the app again.
+	ErrTokenRevoked = errors.New("access token revoked")
+	// ErrRateLimited matches the error of a call refused because the user
+	// or the consumer key ran out of calls.
+	ErrRateLimited = errors.New("rate limit exceeded")
+)
+
+// errorCodeInvalidToken is the X-Error-Code of an access token Pocket no
+// longer accepts. Sent with a 403, it means the user disconnected the app.
+const errorCodeInvalidToken = "107"
+
+// APIError is a response Pocket answered with a status other than 200.
+// Message and Code are its X-Error and X-Error-Code headers; Message is the
+// text to show the user. Authorization failures and rate limits match
+// ErrInvalidToken, ErrTokenRevoked or ErrRateLimited with errors.Is.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Header     http.Header
+
+	endpoint string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API Error : %v", e.Message)
+}
+
+// Unwrap classifies the response. Pocket answers rate limits, revoked
+// tokens and other refusals alike with a 403; rate limits carry an
+// exhausted X-Limit header and revocations the X-Error-Code of an invalid
+// token, while any other 403 is left unclassified. The OAuth endpoints take
+// no access token, so their failures are never about one.
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusTooManyRequests,
+		e.StatusCode == http.StatusForbidden && e.rateLimited():
+		return ErrRateLimited
+	case e.endpoint == endpointRequestToken || e.endpoint == endpointAuthorize:
+		return nil
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrInvalidToken
+	case e.StatusCode == http.StatusForbidden && e.Code == errorCodeInvalidToken:
+		return ErrTokenRevoked
+	}
+
+	return nil
+}
+
+// rateLimited reports whether the user or consumer key ran out of calls.
+func (e *APIError) rateLimited() bool {
+	return e.Header.Get("X-Limit-User-Remaining") == "0" || e.Header.Get("X-Limit-Key-Remaining") == "0"
+}
+
+// ValidationError reports an input rejected before any request was sent.
+// Field is the name of the offending input field.
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+// ActionError reports a send action Pocket did not apply. Err is the cause:
+// a known one such as ErrItemNotFound, or else the *BatchError of the send.
+type ActionError struct {
+	Action ActionType
+	ItemID ItemID
+	Err    error
+}
+
+func (e *ActionError) Error() string {
+	msg := fmt.Sprintf("%s failed", e.Action)
+	if e.ItemID != "" {
+		msg += " for item " + string(e.ItemID)
+	}
+
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", msg, e.Err)
+	}
+
+	return msg
+}
+
+func (e *ActionError) Unwrap() error {
+	return e.Err
+}
+
+// AlreadySavedError is returned by Add with WithSkipIfExists when the URL is
+// already in the list. It matches ErrAlreadySaved with errors.Is.
+type AlreadySavedError struct {
+	Item Item
+}
+
+func (e *AlreadySavedError) Error() string {
+	return fmt.Sprintf("%v as item %s", ErrAlreadySaved, e.Item.ItemID)
+}
+
+func (e *AlreadySavedError) Unwrap() error {
+	return ErrAlreadySaved
+}
+
+// BatchError reports the actions of a Send that Pocket did not apply. Failed
+// holds their indexes in the submitted batch and Actions the actions
+// themselves, in the same order, ready to be sent again.
+type BatchError struct {
+	Failed   []int
+	Actions  []Action
+	Response *SendResponse
+}
+
+func (e *BatchError) Error() string {
+	if len(e.Failed) == 0 {
+		return fmt.Sprintf("send failed with status %d", e.Response.Status)
+	}
+
+	return fmt.Sprintf("%d actions failed, first %s at index %d", len(e.Failed), e.Actions[0].Action, e.Failed[0])
+}
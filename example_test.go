@@ -0,0 +1,57 @@
+package pocket_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	pocket "github.com/Mager556/PocketSDK"
+)
+
+func ExampleClient_Get_domain() {
+	client, err := pocket.NewClient("consumer-key")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	input := pocket.GetInput{
+		AccessToken: "access-token",
+		Domain:      "lwn.net",
+		State:       pocket.StateAll,
+		Count:       30,
+	}
+
+	for {
+		resp, err := client.Get(context.Background(), input)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, item := range resp.Items {
+			fmt.Println(item.ResolvedTitle)
+		}
+
+		if len(resp.Items) < input.Count {
+			break
+		}
+		input.Offset += len(resp.Items)
+	}
+}
+
+func ExampleClient_AddURL() {
+	client, err := pocket.NewClient("consumer-key")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	item, err := client.AddURL(context.Background(), "https://go.dev/blog/",
+		pocket.WithAccessToken("access-token"),
+		pocket.WithTitle("The Go Blog"),
+		pocket.WithTags("go", "blogs"),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(item.ItemID)
+}
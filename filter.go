@@ -0,0 +1,121 @@
+package pocket
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// LangUnknown matches items whose language Pocket failed to detect.
+const LangUnknown = ""
+
+// FilterByLang keeps the items in any of langs, compared case-insensitively.
+// The API has no server-side language filter.
+func FilterByLang(items []Item, langs ...string) []Item {
+	var filtered []Item
+	for _, item := range items {
+		for _, lang := range langs {
+			if strings.EqualFold(item.Lang, lang) {
+				filtered = append(filtered, item)
+				break
+			}
+		}
+	}
+
+	return filtered
+}
+
+// Predicate reports whether an item should be kept. Predicates compose with
+// And, Or and Not.
+type Predicate func(Item) bool
+
+// FilterItems returns the items pred keeps, in their original order. A nil
+// pred keeps everything.
+func FilterItems(items []Item, pred Predicate) []Item {
+	var filtered []Item
+	for _, item := range items {
+		if pred == nil || pred(item) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return filtered
+}
+
+// ByDomain matches items whose Domain is any of domains. Domains are
+// normalized the same way, so "https://www.Example.com/" matches example.com.
+func ByDomain(domains ...string) Predicate {
+	want := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		domain = normalizeDomain(domain)
+		if host := hostOf("//" + domain); host != "" {
+			domain = host
+		}
+		want[domain] = true
+	}
+
+	return func(i Item) bool {
+		return want[i.Domain()]
+	}
+}
+
+// OlderThan matches items saved before t. Items without a TimeAdded are of
+// unknown age and never match, so a bulk delete or archive built on it
+// leaves them alone.
+func OlderThan(t time.Time) Predicate {
+	return func(i Item) bool {
+		return !i.TimeAdded.IsZero() && i.TimeAdded.Before(t)
+	}
+}
+
+// HasTag matches items carrying tag. TagUntagged matches items with no tags.
+// Tags are only populated by complete detail retrieves.
+func HasTag(tag string) Predicate {
+	return func(i Item) bool {
+		if tag == TagUntagged {
+			return len(i.Tags) == 0
+		}
+
+		return slices.Contains(i.Tags, tag)
+	}
+}
+
+// MinWordCount matches items with at least n words.
+func MinWordCount(n int) Predicate {
+	return func(i Item) bool {
+		return i.WordCount >= n
+	}
+}
+
+// And matches items every pred matches; with no preds it matches everything.
+func And(preds ...Predicate) Predicate {
+	return func(i Item) bool {
+		for _, pred := range preds {
+			if !pred(i) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Or matches items any pred matches; with no preds it matches nothing.
+func Or(preds ...Predicate) Predicate {
+	return func(i Item) bool {
+		for _, pred := range preds {
+			if pred(i) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Not inverts pred.
+func Not(pred Predicate) Predicate {
+	return func(i Item) bool {
+		return !pred(i)
+	}
+}
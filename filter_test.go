@@ -0,0 +1,83 @@
+package pocket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterByLang(t *testing.T) {
+	items := []Item{
+		{ItemID: "1", Lang: "en"},
+		{ItemID: "2", Lang: "ru"},
+		{ItemID: "3", Lang: ""},
+		{ItemID: "4", Lang: "EN"},
+		{ItemID: "5", Lang: "de"},
+	}
+
+	tests := []struct {
+		name  string
+		langs []string
+		want  []string
+	}{
+		{name: "Single language", langs: []string{"en"}, want: []string{"1", "4"}},
+		{name: "Two languages", langs: []string{"ru", "en"}, want: []string{"1", "2", "4"}},
+		{name: "Unknown language", langs: []string{LangUnknown}, want: []string{"3"}},
+		{name: "No match", langs: []string{"fr"}, want: nil},
+		{name: "No languages", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FilterByLang(items, tt.langs...)
+			assert.Equal(t, tt.want, idsOrNil(got))
+		})
+	}
+}
+
+func TestFilterItems(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	items := []Item{
+		{ItemID: "1", ResolvedURL: "https://www.example.com/a", TimeAdded: day(1), WordCount: 1200, Tags: []string{"go"}},
+		{ItemID: "2", GivenURL: "https://Blog.Example.org/b", TimeAdded: day(5), WordCount: 300},
+		{ItemID: "3", ResolvedURL: "https://news.ycombinator.com/item?id=1", TimeAdded: day(10), WordCount: 0, Tags: []string{"go", "hn"}},
+		{ItemID: "4", ResolvedURL: "https://example.com:8443/c", TimeAdded: day(20), WordCount: 2500, Tags: []string{"read-later"}},
+	}
+
+	tests := []struct {
+		name string
+		pred Predicate
+		want []string
+	}{
+		{name: "Nil keeps all", pred: nil, want: []string{"1", "2", "3", "4"}},
+		{name: "Domain", pred: ByDomain("example.com"), want: []string{"1", "4"}},
+		{name: "Domain pasted as URL", pred: ByDomain("https://WWW.example.com/"), want: []string{"1", "4"}},
+		{name: "Several domains", pred: ByDomain("blog.example.org", "news.ycombinator.com"), want: []string{"2", "3"}},
+		{name: "Older than", pred: OlderThan(day(10)), want: []string{"1", "2"}},
+		{name: "Has tag", pred: HasTag("go"), want: []string{"1", "3"}},
+		{name: "Untagged", pred: HasTag(TagUntagged), want: []string{"2"}},
+		{name: "Min word count", pred: MinWordCount(1000), want: []string{"1", "4"}},
+		{name: "And", pred: And(ByDomain("example.com"), OlderThan(day(10))), want: []string{"1"}},
+		{name: "Empty And", pred: And(), want: []string{"1", "2", "3", "4"}},
+		{name: "Or", pred: Or(HasTag("hn"), MinWordCount(2000)), want: []string{"3", "4"}},
+		{name: "Empty Or", pred: Or(), want: nil},
+		{name: "Not", pred: Not(HasTag("go")), want: []string{"2", "4"}},
+		{name: "Plain func", pred: func(i Item) bool { return i.ItemID == "2" }, want: []string{"2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, idsOrNil(FilterItems(items, tt.pred)))
+		})
+	}
+}
+
+func TestOlderThan_UnknownTime(t *testing.T) {
+	cutoff := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, OlderThan(cutoff)(Item{TimeAdded: cutoff.Add(-time.Hour)}))
+	assert.False(t, OlderThan(cutoff)(Item{TimeAdded: cutoff}))
+	assert.False(t, OlderThan(cutoff)(Item{}))
+}
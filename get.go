@@ -0,0 +1,431 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxCount is the largest page size Pocket documents for the retrieve endpoint.
+const maxCount = 30
+
+type State string
+
+const (
+	StateUnread  State = "unread"
+	StateArchive State = "archive"
+	StateAll     State = "all"
+)
+
+func (s State) valid() bool {
+	switch s {
+	case "", StateUnread, StateArchive, StateAll:
+		return true
+	}
+
+	return false
+}
+
+type ContentType string
+
+const (
+	ContentTypeArticle ContentType = "article"
+	ContentTypeVideo   ContentType = "video"
+	ContentTypeImage   ContentType = "image"
+)
+
+func (t ContentType) valid() bool {
+	switch t {
+	case "", ContentTypeArticle, ContentTypeVideo, ContentTypeImage:
+		return true
+	}
+
+	return false
+}
+
+type Sort string
+
+const (
+	SortNewest Sort = "newest"
+	SortOldest Sort = "oldest"
+	SortTitle  Sort = "title"
+	SortSite   Sort = "site"
+)
+
+func (s Sort) valid() bool {
+	switch s {
+	case "", SortNewest, SortOldest, SortTitle, SortSite:
+		return true
+	}
+
+	return false
+}
+
+// Detail controls how much data Pocket returns per item. Simple is the server
+// default and keeps payloads small; complete adds tags, authors, images and
+// videos.
+type Detail string
+
+const (
+	DetailSimple   Detail = "simple"
+	DetailComplete Detail = "complete"
+)
+
+func (d Detail) valid() bool {
+	switch d {
+	case "", DetailSimple, DetailComplete:
+		return true
+	}
+
+	return false
+}
+
+// TagUntagged is the magic tag value that retrieves only items without tags.
+const TagUntagged = "_untagged_"
+
+// FavoriteFilter is tri-state: the zero value does not filter at all, which
+// a plain bool could not express.
+type FavoriteFilter int
+
+const (
+	FavoriteAny FavoriteFilter = iota
+	FavoriteOnly
+	FavoriteExclude
+)
+
+func (f FavoriteFilter) param() string {
+	switch f {
+	case FavoriteOnly:
+		return "1"
+	case FavoriteExclude:
+		return "0"
+	}
+
+	return ""
+}
+
+func (f FavoriteFilter) valid() bool {
+	return f >= FavoriteAny && f <= FavoriteExclude
+}
+
+type (
+	getRequest struct {
+		ConsumerKey string      `json:"consumer_key"`
+		AccessToken string      `json:"access_token"`
+		Count       int         `json:"count,omitempty"`
+		Offset      int         `json:"offset,omitempty"`
+		State       State       `json:"state,omitempty"`
+		Favorite    string      `json:"favorite,omitempty"`
+		Tag         string      `json:"tag,omitempty"`
+		ContentType ContentType `json:"contentType,omitempty"`
+		Sort        Sort        `json:"sort,omitempty"`
+		DetailType  Detail      `json:"detailType,omitempty"`
+		Search      string      `json:"search,omitempty"`
+		Domain      string      `json:"domain,omitempty"`
+		Since       int64       `json:"since,omitempty"`
+		Total       string      `json:"total,omitempty"`
+	}
+
+	GetInput struct {
+		// AccessToken defaults to the client's; see Client.WithAccessToken.
+		AccessToken string
+		Count       int
+		Offset      int
+		State       State
+		Favorite    FavoriteFilter
+		Tag         string
+		ContentType ContentType
+		Sort        Sort
+		Detail      Detail
+		Search      string
+		Domain      string
+		Since       time.Time
+		Total       bool
+	}
+
+	GetResponse struct {
+		Items []Item
+		// Since is the server time of the response; pass it as
+		// GetInput.Since on the next delta retrieve.
+		Since time.Time
+		// Total is the number of items matching the filters, or -1 when
+		// GetInput.Total was not set and the server did not report it.
+		Total int
+	}
+)
+
+func (i GetInput) validate() error {
+	if i.AccessToken == "" {
+		return errNoAccessToken()
+	}
+
+	if i.Count < 0 || i.Count > maxCount {
+		return &ValidationError{Field: "Count", Reason: fmt.Sprintf("must be between 1 and %d", maxCount)}
+	}
+
+	if i.Offset < 0 {
+		return &ValidationError{Field: "Offset", Reason: "is negative"}
+	}
+
+	if !i.State.valid() {
+		return &ValidationError{Field: "State", Reason: fmt.Sprintf("unknown value %q", i.State)}
+	}
+
+	if !i.Favorite.valid() {
+		return &ValidationError{Field: "Favorite", Reason: fmt.Sprintf("unknown value %d", i.Favorite)}
+	}
+
+	if strings.Contains(i.Tag, ",") {
+		return &ValidationError{Field: "Tag", Reason: fmt.Sprintf("%q must not contain commas", i.Tag)}
+	}
+
+	if !i.ContentType.valid() {
+		return &ValidationError{Field: "ContentType", Reason: fmt.Sprintf("unknown value %q", i.ContentType)}
+	}
+
+	if !i.Sort.valid() {
+		return &ValidationError{Field: "Sort", Reason: fmt.Sprintf("unknown value %q", i.Sort)}
+	}
+
+	if !i.Detail.valid() {
+		return &ValidationError{Field: "Detail", Reason: fmt.Sprintf("unknown value %q", i.Detail)}
+	}
+
+	// encoding/json would silently replace invalid bytes with U+FFFD.
+	if !utf8.ValidString(i.Search) {
+		return &ValidationError{Field: "Search", Reason: "is not valid UTF-8"}
+	}
+
+	if strings.ContainsFunc(normalizeDomain(i.Domain), unicode.IsSpace) {
+		return &ValidationError{Field: "Domain", Reason: fmt.Sprintf("%q must not contain spaces", i.Domain)}
+	}
+
+	return nil
+}
+
+func (i GetInput) generateRequest(consumerKey string) getRequest {
+	return getRequest{
+		ConsumerKey: consumerKey,
+		AccessToken: i.AccessToken,
+		Count:       i.Count,
+		Offset:      i.Offset,
+		State:       i.State,
+		Favorite:    i.Favorite.param(),
+		Tag:         i.Tag,
+		ContentType: i.ContentType,
+		Sort:        i.Sort,
+		DetailType:  i.Detail,
+		Search:      i.Search,
+		Domain:      normalizeDomain(i.Domain),
+		Since:       unixSeconds(i.Since),
+		Total:       boolParam(i.Total),
+	}
+}
+
+// boolParam encodes an opt-in flag the way Pocket expects, leaving false out
+// of the request entirely.
+func boolParam(b bool) string {
+	if b {
+		return "1"
+	}
+
+	return ""
+}
+
+// unixSeconds converts t for the wire, mapping the zero time to 0 so that
+// omitempty drops it. Every timestamp the SDK sends goes through here. The
+// result counts seconds since the epoch in UTC, whatever t's location, so
+// the same instant always serializes the same way.
+func unixSeconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UTC().Unix()
+}
+
+// normalizeDomain strips a scheme and trailing slash that callers often
+// paste along with the host, since Pocket expects a bare domain.
+func normalizeDomain(domain string) string {
+	domain = strings.TrimSpace(domain)
+	if i := strings.Index(domain, "://"); i >= 0 {
+		domain = domain[i+3:]
+	}
+
+	return strings.TrimSuffix(domain, "/")
+}
+
+func (c *Client) Get(ctx context.Context, input GetInput) (*GetResponse, error) {
+	input.AccessToken = c.token(input.AccessToken)
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	inp := input.generateRequest(c.consumerKey)
+
+	var resp *GetResponse
+	err := c.doStream(ctx, endpointGet, inp, func(r io.Reader) error {
+		var items []Item
+		var err error
+
+		resp, err = decodeGetResponse(r, func(item Item) error {
+			items = append(items, item)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		sortBySortID(items)
+		resp.Items = items
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Items == nil {
+		resp.Items = []Item{}
+	}
+
+	return resp, nil
+}
+
+// sortBySortID restores the order Pocket computed (relevance for searches,
+// the requested sort otherwise), which only survives in sort_id because the
+// list is a JSON object. Items without a distinct sort_id keep the order they
+// appeared in the payload.
+func sortBySortID(items []Item) {
+	sort.SliceStable(items, func(a, b int) bool {
+		return items[a].SortID < items[b].SortID
+	})
+}
+
+// decodeGetResponse walks a retrieve response token by token, handing each
+// entry of the list to emit as soon as it is decoded so the raw payload is
+// never held in memory as a whole. The returned response has no Items.
+func decodeGetResponse(r io.Reader, emit func(Item) error) (*GetResponse, error) {
+	dec := json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	resp := &GetResponse{Total: -1}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch tok {
+		case "list":
+			err = decodeList(dec, emit)
+		case "since":
+			var raw json.RawMessage
+			if err = dec.Decode(&raw); err == nil {
+				resp.Since, err = parseUnixTime(raw)
+			}
+		case "total":
+			var raw json.RawMessage
+			if err = dec.Decode(&raw); err == nil && string(raw) != "null" {
+				resp.Total, err = parseStringInt(raw)
+			}
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("field %v: %w", tok, err)
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// decodeList decodes the list object keyed by item ID one entry at a time.
+// Pocket sends an empty array instead of an object when nothing matches, so
+// arrays are accepted as well.
+func decodeList(dec *json.Decoder, emit func(Item) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	var end json.Delim
+	switch tok {
+	case nil:
+		return nil
+	case json.Delim('{'):
+		end = '}'
+	case json.Delim('['):
+		end = ']'
+	default:
+		return fmt.Errorf("unexpected %v", tok)
+	}
+
+	for dec.More() {
+		if end == '}' {
+			if _, err := dec.Token(); err != nil {
+				return err
+			}
+		}
+
+		var item Item
+		if err := dec.Decode(&item); err != nil {
+			return err
+		}
+
+		if err := emit(item); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, end)
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok != want {
+		return fmt.Errorf("expected %v, got %v", want, tok)
+	}
+
+	return nil
+}
+
+// Count returns how many items match the filters in input without
+// downloading them, using the smallest retrieve Pocket accepts. Count and
+// Offset on input are ignored.
+func (c *Client) Count(ctx context.Context, input GetInput) (int, error) {
+	input.Count = 1
+	input.Offset = 0
+	input.Total = true
+	input.Detail = DetailSimple
+
+	resp, err := c.Get(ctx, input)
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.Total < 0 {
+		return 0, errors.New("Total is missing in API response")
+	}
+
+	return resp.Total, nil
+}
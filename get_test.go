@@ -0,0 +1,744 @@
+package pocket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Get(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      GetInput
+		response   string
+		statusCode int
+		want       *GetResponse
+		wantErr    bool
+	}{
+		{
+			name: "Default-OK",
+			input: GetInput{
+				AccessToken: "access-to-ken",
+				Count:       10,
+			},
+			response: `{"status":1,"complete":1,"list":{` +
+				`"229279689":{"item_id":"229279689","resolved_id":"229279689","given_url":"http://www.grantland.com/blog/the-triangle/post/_/id/38347/ryder-cup-preview","given_title":"The Massive Ryder Cup Preview"},` +
+				`"229279690":{"item_id":"229279690","resolved_id":"229279690","given_url":"https://go.dev/blog","given_title":"The Go Blog"}` +
+				`},"error":null,"since":1245626956}`,
+			statusCode: 200,
+			want: &GetResponse{
+				Items: []Item{
+					{
+						ItemID:     "229279689",
+						ResolvedID: "229279689",
+						GivenURL:   "http://www.grantland.com/blog/the-triangle/post/_/id/38347/ryder-cup-preview",
+						GivenTitle: "The Massive Ryder Cup Preview",
+					},
+					{
+						ItemID:     "229279690",
+						ResolvedID: "229279690",
+						GivenURL:   "https://go.dev/blog",
+						GivenTitle: "The Go Blog",
+					},
+				},
+				Since: time.Unix(1245626956, 0).UTC(),
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Empty accessToken",
+			input:   GetInput{Count: 10},
+			wantErr: true,
+		},
+		{
+			name:    "Negative offset",
+			input:   GetInput{AccessToken: "access-to-ken", Offset: -1},
+			wantErr: true,
+		},
+		{
+			name:       "Invalid JSON response",
+			input:      GetInput{AccessToken: "access-to-ken"},
+			response:   "status=1",
+			statusCode: 200,
+			wantErr:    true,
+		},
+		{
+			name:       "Non-2XX Response",
+			input:      GetInput{AccessToken: "access-to-ken"},
+			statusCode: 401,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t, tt.statusCode, "/v3/get", tt.response)
+
+			got, err := client.Get(context.Background(), tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want.Since, got.Since)
+				assert.ElementsMatch(t, tt.want.Items, got.Items)
+			}
+		})
+	}
+}
+
+type getBodyTest struct {
+	name     string
+	input    GetInput
+	wantBody string
+	wantErr  bool
+}
+
+// runGetBodyTests asserts the exact JSON body Get sends for every input, and
+// that invalid inputs never reach the transport.
+func runGetBodyTests(t *testing.T, tests []getBodyTest) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClientWithCheck(t, 200, "/v3/get", `{"status":1,"list":{}}`, func(r *http.Request) {
+				if tt.wantErr {
+					t.Fatal("request must not be sent for invalid input")
+				}
+				assert.JSONEq(t, tt.wantBody, readBody(t, r))
+			})
+
+			input := tt.input
+			input.AccessToken = "access-to-ken"
+
+			_, err := client.Get(context.Background(), input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestClient_Get_State(t *testing.T) {
+	runGetBodyTests(t, []getBodyTest{
+		{
+			name:     "Default state omitted",
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken"}`,
+		},
+		{
+			name:     "Unread",
+			input:    GetInput{State: StateUnread},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","state":"unread"}`,
+		},
+		{
+			name:     "Archive",
+			input:    GetInput{State: StateArchive},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","state":"archive"}`,
+		},
+		{
+			name:     "All",
+			input:    GetInput{State: StateAll},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","state":"all"}`,
+		},
+		{
+			name:    "Unknown state",
+			input:   GetInput{State: State("deleted")},
+			wantErr: true,
+		},
+	})
+}
+
+func TestClient_Get_Favorite(t *testing.T) {
+	runGetBodyTests(t, []getBodyTest{
+		{
+			name:     "Any omits favorite",
+			input:    GetInput{Favorite: FavoriteAny},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken"}`,
+		},
+		{
+			name:     "Only favorites",
+			input:    GetInput{Favorite: FavoriteOnly},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","favorite":"1"}`,
+		},
+		{
+			name:     "Exclude favorites",
+			input:    GetInput{Favorite: FavoriteExclude},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","favorite":"0"}`,
+		},
+		{
+			name:    "Unknown filter",
+			input:   GetInput{Favorite: FavoriteFilter(7)},
+			wantErr: true,
+		},
+	})
+}
+
+func TestClient_Get_Tag(t *testing.T) {
+	runGetBodyTests(t, []getBodyTest{
+		{
+			name:     "Normal tag",
+			input:    GetInput{Tag: "golang"},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","tag":"golang"}`,
+		},
+		{
+			name:     "Untagged",
+			input:    GetInput{Tag: TagUntagged},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","tag":"_untagged_"}`,
+		},
+		{
+			name:    "Tag with comma",
+			input:   GetInput{Tag: "go,rust"},
+			wantErr: true,
+		},
+	})
+}
+
+func TestClient_Get_ContentType(t *testing.T) {
+	runGetBodyTests(t, []getBodyTest{
+		{
+			name:     "Article",
+			input:    GetInput{ContentType: ContentTypeArticle},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","contentType":"article"}`,
+		},
+		{
+			name:     "Video",
+			input:    GetInput{ContentType: ContentTypeVideo},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","contentType":"video"}`,
+		},
+		{
+			name:     "Image",
+			input:    GetInput{ContentType: ContentTypeImage},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","contentType":"image"}`,
+		},
+		{
+			name:    "Unknown content type",
+			input:   GetInput{ContentType: ContentType("podcast")},
+			wantErr: true,
+		},
+	})
+}
+
+func TestClient_Get_Sort(t *testing.T) {
+	runGetBodyTests(t, []getBodyTest{
+		{
+			name:     "Newest",
+			input:    GetInput{Sort: SortNewest},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","sort":"newest"}`,
+		},
+		{
+			name:     "Oldest",
+			input:    GetInput{Sort: SortOldest},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","sort":"oldest"}`,
+		},
+		{
+			name:     "Title",
+			input:    GetInput{Sort: SortTitle},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","sort":"title"}`,
+		},
+		{
+			name:     "Site",
+			input:    GetInput{Sort: SortSite},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","sort":"site"}`,
+		},
+		{
+			name:    "Unknown sort",
+			input:   GetInput{Sort: Sort("random")},
+			wantErr: true,
+		},
+	})
+}
+
+func TestClient_Get_SortOrder(t *testing.T) {
+	response := `{"status":1,"list":{` +
+		`"1":{"item_id":"1","sort_id":3},` +
+		`"2":{"item_id":"2","sort_id":0},` +
+		`"3":{"item_id":"3","sort_id":4},` +
+		`"4":{"item_id":"4","sort_id":1},` +
+		`"5":{"item_id":"5","sort_id":2}` +
+		`}}`
+
+	client := newClient(t, 200, "/v3/get", response)
+
+	got, err := client.Get(context.Background(), GetInput{AccessToken: "access-to-ken", Sort: SortOldest})
+	assert.NoError(t, err)
+
+	ids := make([]string, 0, len(got.Items))
+	for _, item := range got.Items {
+		ids = append(ids, string(item.ItemID))
+	}
+	assert.Equal(t, []string{"2", "4", "5", "1", "3"}, ids)
+}
+
+func TestClient_Get_Detail(t *testing.T) {
+	runGetBodyTests(t, []getBodyTest{
+		{
+			name:     "Default detail omitted",
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken"}`,
+		},
+		{
+			name:     "Simple",
+			input:    GetInput{Detail: DetailSimple},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","detailType":"simple"}`,
+		},
+		{
+			name:     "Complete",
+			input:    GetInput{Detail: DetailComplete},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","detailType":"complete"}`,
+		},
+		{
+			name:    "Unknown detail",
+			input:   GetInput{Detail: Detail("full")},
+			wantErr: true,
+		},
+	})
+}
+
+func TestClient_Get_Search(t *testing.T) {
+	runGetBodyTests(t, []getBodyTest{
+		{
+			name:     "ASCII query",
+			input:    GetInput{Search: "ryder cup"},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","search":"ryder cup"}`,
+		},
+		{
+			name:     "Unicode query",
+			input:    GetInput{Search: "горутины & каналы"},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","search":"горутины & каналы"}`,
+		},
+		{
+			name:    "Invalid UTF-8",
+			input:   GetInput{Search: "\xff\xfe"},
+			wantErr: true,
+		},
+	})
+}
+
+func TestClient_Get_SearchRelevanceOrder(t *testing.T) {
+	response := `{"status":1,"list":{` +
+		`"100":{"item_id":"100","given_title":"Каналы в Go","sort_id":1},` +
+		`"200":{"item_id":"200","given_title":"Горутины и каналы","sort_id":0}` +
+		`}}`
+
+	client := newClient(t, 200, "/v3/get", response)
+
+	got, err := client.Get(context.Background(), GetInput{AccessToken: "access-to-ken", Search: "каналы"})
+	assert.NoError(t, err)
+	if assert.Len(t, got.Items, 2) {
+		assert.Equal(t, ItemID("200"), got.Items[0].ItemID)
+		assert.Equal(t, ItemID("100"), got.Items[1].ItemID)
+	}
+}
+
+func TestClient_Get_Domain(t *testing.T) {
+	runGetBodyTests(t, []getBodyTest{
+		{
+			name:     "Bare domain",
+			input:    GetInput{Domain: "lwn.net"},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","domain":"lwn.net"}`,
+		},
+		{
+			name:     "Scheme stripped",
+			input:    GetInput{Domain: "https://lwn.net/"},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","domain":"lwn.net"}`,
+		},
+		{
+			name:    "Domain with spaces",
+			input:   GetInput{Domain: "lwn net"},
+			wantErr: true,
+		},
+	})
+}
+
+func TestClient_Get_Since(t *testing.T) {
+	runGetBodyTests(t, []getBodyTest{
+		{
+			name:     "Zero since omitted",
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken"}`,
+		},
+		{
+			name:     "Since as unix seconds",
+			input:    GetInput{Since: time.Date(2016, 8, 22, 12, 41, 52, 0, time.UTC)},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","since":1471869712}`,
+		},
+	})
+}
+
+func TestClient_Get_SinceSparseItems(t *testing.T) {
+	response := `{"status":1,"complete":1,"list":{` +
+		`"229279689":{"item_id":"229279689","resolved_id":"229279689","given_url":"https://lwn.net/Articles/1/","status":"0","time_added":"1471869712","sort_id":0},` +
+		`"229279690":{"item_id":"229279690","status":"1","sort_id":1},` +
+		`"229279691":{"item_id":"229279691","status":"2","sort_id":2}` +
+		`},"error":null,"since":1471870000}`
+
+	client := newClient(t, 200, "/v3/get", response)
+
+	got, err := client.Get(context.Background(), GetInput{
+		AccessToken: "access-to-ken",
+		State:       StateAll,
+		Since:       time.Unix(1471869000, 0),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(1471870000, 0).UTC(), got.Since)
+	assert.Equal(t, []Item{
+		{
+			ItemID:     "229279689",
+			ResolvedID: "229279689",
+			GivenURL:   "https://lwn.net/Articles/1/",
+			Status:     ItemStatusUnread,
+			TimeAdded:  time.Unix(1471869712, 0).UTC(),
+		},
+		{ItemID: "229279690", Status: ItemStatusArchived, SortID: 1},
+		{ItemID: "229279691", Status: ItemStatusDeleted, SortID: 2},
+	}, got.Items)
+}
+
+func TestClient_Get_Pagination(t *testing.T) {
+	runGetBodyTests(t, []getBodyTest{
+		{
+			name:     "Zero values omitted",
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken"}`,
+		},
+		{
+			name:     "Count and offset",
+			input:    GetInput{Count: 30, Offset: 60},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","count":30,"offset":60}`,
+		},
+		{
+			name:     "Minimal count",
+			input:    GetInput{Count: 1},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","count":1}`,
+		},
+		{
+			name:    "Count over max",
+			input:   GetInput{Count: 31},
+			wantErr: true,
+		},
+		{
+			name:    "Negative count",
+			input:   GetInput{Count: -1},
+			wantErr: true,
+		},
+		{
+			name:    "Negative offset",
+			input:   GetInput{Offset: -30},
+			wantErr: true,
+		},
+	})
+}
+
+func TestClient_Get_Total(t *testing.T) {
+	runGetBodyTests(t, []getBodyTest{
+		{
+			name:     "Total requested",
+			input:    GetInput{Total: true, Count: 1},
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","count":1,"total":"1"}`,
+		},
+	})
+
+	tests := []struct {
+		name     string
+		response string
+		want     int
+		wantErr  bool
+	}{
+		{
+			name:     "String total",
+			response: `{"status":1,"list":{"1":{"item_id":"1"}},"total":"1742"}`,
+			want:     1742,
+		},
+		{
+			name:     "Numeric total",
+			response: `{"status":1,"list":{"1":{"item_id":"1"}},"total":1742}`,
+			want:     1742,
+		},
+		{
+			name:     "Total absent",
+			response: `{"status":1,"list":{"1":{"item_id":"1"}}}`,
+			want:     -1,
+		},
+		{
+			name:     "Malformed total",
+			response: `{"status":1,"list":{},"total":"lots"}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t, 200, "/v3/get", tt.response)
+
+			got, err := client.Get(context.Background(), GetInput{AccessToken: "access-to-ken", Total: true, Count: 1})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got.Total)
+			}
+		})
+	}
+}
+
+func TestClient_Count(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    GetInput
+		response string
+		wantBody string
+		want     int
+		wantErr  bool
+	}{
+		{
+			name:     "Unread badge",
+			input:    GetInput{AccessToken: "access-to-ken", State: StateUnread},
+			response: `{"status":1,"list":{"1":{"item_id":"1"}},"total":"42"}`,
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","count":1,"state":"unread","detailType":"simple","total":"1"}`,
+			want:     42,
+		},
+		{
+			name:     "By tag overrides paging",
+			input:    GetInput{AccessToken: "access-to-ken", Tag: "golang", Count: 30, Offset: 90, Detail: DetailComplete},
+			response: `{"status":1,"list":{},"total":"0"}`,
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","count":1,"tag":"golang","detailType":"simple","total":"1"}`,
+			want:     0,
+		},
+		{
+			name:     "Total missing",
+			input:    GetInput{AccessToken: "access-to-ken"},
+			response: `{"status":1,"list":{}}`,
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","count":1,"detailType":"simple","total":"1"}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClientWithCheck(t, 200, "/v3/get", tt.response, func(r *http.Request) {
+				assert.JSONEq(t, tt.wantBody, readBody(t, r))
+			})
+
+			got, err := client.Count(context.Background(), tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+// largeGetResponse builds a complete-detail style retrieve payload with n
+// items.
+func largeGetResponse(n int) []byte {
+	var b strings.Builder
+
+	b.WriteString(`{"status":1,"complete":1,"list":{`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `"%d":{"item_id":"%d","resolved_id":"%d",`+
+			`"given_url":"https://example.com/articles/%d","resolved_url":"https://example.com/articles/%d",`+
+			`"given_title":"Article %d","resolved_title":"Article %d",`+
+			`"excerpt":"%s","favorite":"0","status":"0","word_count":"1234","time_to_read":6,`+
+			`"time_added":"1471869712","time_updated":"1471869712","time_read":"0","time_favorited":"0",`+
+			`"sort_id":%d,"is_article":"1","is_index":"0","has_video":"0","has_image":"1",`+
+			`"tags":{"go":{"item_id":"%d","tag":"go"}},`+
+			`"images":{"1":{"item_id":"%d","image_id":"1","src":"https://example.com/%d.png","width":"640","height":"480","credit":"","caption":""}}}`,
+			i, i, i, i, i, i, i, strings.Repeat("lorem ipsum dolor sit amet ", 20), i, i, i, i)
+	}
+	b.WriteString(`},"error":null,"since":1471870000}`)
+
+	return []byte(b.String())
+}
+
+func BenchmarkDecodeGetResponse(b *testing.B) {
+	payload := largeGetResponse(5000)
+
+	// readall mirrors the previous approach: buffer the whole body, then
+	// decode the list into a map before copying it into a slice.
+	b.Run("readall", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			raw, err := io.ReadAll(bytes.NewReader(payload))
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			var resp struct {
+				List map[string]Item `json:"list"`
+			}
+			if err := json.Unmarshal(raw, &resp); err != nil {
+				b.Fatal(err)
+			}
+
+			items := make([]Item, 0, len(resp.List))
+			for _, item := range resp.List {
+				items = append(items, item)
+			}
+		}
+	})
+
+	b.Run("stream", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			count := 0
+			_, err := decodeGetResponse(bytes.NewReader(payload), func(Item) error {
+				count++
+				return nil
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestClient_Get_ListShapes(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "Empty array",
+			response: `{"status":2,"complete":1,"list":[],"error":null,"search_meta":{"search_type":"normal"},"since":1471870000}`,
+			want:     []string{},
+		},
+		{
+			name:     "Empty object",
+			response: `{"status":1,"complete":1,"list":{},"error":null,"since":1471870000}`,
+			want:     []string{},
+		},
+		{
+			name:     "Null list",
+			response: `{"status":1,"list":null,"since":1471870000}`,
+			want:     []string{},
+		},
+		{
+			name:     "Populated object",
+			response: `{"status":1,"list":{"7":{"item_id":"7","sort_id":1},"9":{"item_id":"9","sort_id":0}},"since":1471870000}`,
+			want:     []string{"9", "7"},
+		},
+		{
+			name:     "List as string",
+			response: `{"status":1,"list":"none","since":1471870000}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t, 200, "/v3/get", tt.response)
+
+			got, err := client.Get(context.Background(), GetInput{AccessToken: "access-to-ken"})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, itemIDs(got.Items))
+				assert.NotNil(t, got.Items)
+			}
+		})
+	}
+}
+
+func TestGetInput_validate(t *testing.T) {
+	valid := GetInput{AccessToken: "access-to-ken"}
+
+	tests := []struct {
+		name      string
+		modify    func(*GetInput)
+		wantField string
+	}{
+		{name: "Valid", modify: func(*GetInput) {}},
+		{name: "Valid combination", modify: func(i *GetInput) {
+			i.State, i.Favorite, i.Tag, i.ContentType = StateArchive, FavoriteOnly, "golang", ContentTypeImage
+		}},
+		{name: "Empty access token", modify: func(i *GetInput) { i.AccessToken = "" }, wantField: "AccessToken"},
+		{name: "Count over max", modify: func(i *GetInput) { i.Count = 31 }, wantField: "Count"},
+		{name: "Negative offset", modify: func(i *GetInput) { i.Offset = -1 }, wantField: "Offset"},
+		{name: "Unknown state", modify: func(i *GetInput) { i.State = "deleted" }, wantField: "State"},
+		{name: "Unknown favorite", modify: func(i *GetInput) { i.Favorite = 3 }, wantField: "Favorite"},
+		{name: "Tag with comma", modify: func(i *GetInput) { i.Tag = "a,b" }, wantField: "Tag"},
+		{name: "Unknown content type", modify: func(i *GetInput) { i.ContentType = "audio" }, wantField: "ContentType"},
+		{name: "Unknown sort", modify: func(i *GetInput) { i.Sort = "relevance" }, wantField: "Sort"},
+		{name: "Unknown detail", modify: func(i *GetInput) { i.Detail = "full" }, wantField: "Detail"},
+		{name: "Invalid search", modify: func(i *GetInput) { i.Search = "\xff" }, wantField: "Search"},
+		{name: "Domain with spaces", modify: func(i *GetInput) { i.Domain = "a b.com" }, wantField: "Domain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := valid
+			tt.modify(&input)
+
+			err := input.validate()
+			if tt.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var verr *ValidationError
+			if assert.ErrorAs(t, err, &verr) {
+				assert.Equal(t, tt.wantField, verr.Field)
+				assert.Contains(t, err.Error(), tt.wantField)
+			}
+		})
+	}
+}
+
+func TestUnixSeconds(t *testing.T) {
+	mustLoad := func(name string) *time.Location {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return loc
+	}
+	newYork := mustLoad("America/New_York")
+	sydney := mustLoad("Australia/Sydney")
+
+	tests := []struct {
+		name string
+		in   time.Time
+		want int64
+	}{
+		{name: "Zero", in: time.Time{}, want: 0},
+		{name: "Zero in a location", in: time.Time{}.In(sydney), want: 0},
+		{name: "UTC", in: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), want: 1705320000},
+		// 23:00 on the 15th in Sydney (UTC+11 in summer) is 12:00 UTC.
+		{name: "Sydney summer", in: time.Date(2024, 1, 15, 23, 0, 0, 0, sydney), want: 1705320000},
+		{name: "Half-hour offset", in: time.Date(2024, 1, 15, 17, 30, 0, 0, time.FixedZone("IST", 5*3600+1800)), want: 1705320000},
+		// Spring forward: 01:59:59 EST is followed by 03:00:00 EDT.
+		{name: "Before spring forward", in: time.Date(2024, 3, 10, 1, 59, 59, 0, newYork), want: 1710053999},
+		{name: "After spring forward", in: time.Date(2024, 3, 10, 3, 0, 0, 0, newYork), want: 1710054000},
+		// Fall back: 01:30 happens twice, and time.Date does not promise which
+		// one it picks, so both are built from their UTC instants.
+		{name: "Fall back, EDT", in: time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC).In(newYork), want: 1730611800},
+		{name: "Fall back, EST", in: time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC).In(newYork), want: 1730615400},
+		// Sydney leaves daylight saving at 03:00 AEDT, repeating 02:00-03:00.
+		{name: "Sydney fall back, AEDT", in: time.Date(2024, 4, 6, 15, 30, 0, 0, time.UTC).In(sydney), want: 1712417400},
+		{name: "Sydney fall back, AEST", in: time.Date(2024, 4, 6, 16, 30, 0, 0, time.UTC).In(sydney), want: 1712421000},
+		{name: "Sub-second", in: time.Date(2024, 1, 15, 12, 0, 0, 999999999, time.UTC), want: 1705320000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, unixSeconds(tt.in))
+			assert.Equal(t, tt.want, unixSeconds(tt.in.UTC()))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package pocket
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// monthLayout is the key format GroupByMonth uses.
+const monthLayout = "2006-01"
+
+// GroupByDomain groups items by Domain, so hosts differing only in case or a
+// www. prefix share a group. Items without a usable URL are grouped under "".
+// Each group keeps the input order.
+func GroupByDomain(items []Item) map[string][]Item {
+	return groupBy(items, Item.Domain)
+}
+
+// GroupByMonth groups items by the UTC month they were saved in, keyed like
+// "2024-03". Items without a save time are grouped under "".
+func GroupByMonth(items []Item) map[string][]Item {
+	return groupBy(items, func(i Item) string {
+		if i.TimeAdded.IsZero() {
+			return ""
+		}
+
+		return i.TimeAdded.UTC().Format(monthLayout)
+	})
+}
+
+func groupBy(items []Item, key func(Item) string) map[string][]Item {
+	groups := make(map[string][]Item)
+	for _, item := range items {
+		k := key(item)
+		groups[k] = append(groups[k], item)
+	}
+
+	return groups
+}
+
+// SortedGroups iterates groups in ascending key order, which is
+// chronological for GroupByMonth keys.
+func SortedGroups(groups map[string][]Item) iter.Seq2[string, []Item] {
+	return func(yield func(string, []Item) bool) {
+		for _, key := range slices.Sorted(maps.Keys(groups)) {
+			if !yield(key, groups[key]) {
+				return
+			}
+		}
+	}
+}
+
+// GroupKeysBySize returns the keys of groups, largest group first, breaking
+// ties by key.
+func GroupKeysBySize(groups map[string][]Item) []string {
+	keys := slices.Collect(maps.Keys(groups))
+	slices.SortFunc(keys, func(a, b string) int {
+		if n := len(groups[b]) - len(groups[a]); n != 0 {
+			return n
+		}
+		return strings.Compare(a, b)
+	})
+
+	return keys
+}
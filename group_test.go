@@ -0,0 +1,76 @@
+package pocket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func groupFixture() []Item {
+	at := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 12, 0, 0, 0, time.UTC) }
+
+	return []Item{
+		{ItemID: "1", ResolvedURL: "https://www.Example.com/a", TimeAdded: at(2024, time.March, 1)},
+		{ItemID: "2", GivenURL: "https://EXAMPLE.com/b", TimeAdded: at(2024, time.March, 31)},
+		{ItemID: "3", ResolvedURL: "https://blog.go.dev/x", TimeAdded: at(2024, time.February, 29)},
+		{ItemID: "4", GivenURL: "https://www.example.com:443/c", TimeAdded: at(2023, time.December, 5)},
+		{ItemID: "5", TimeAdded: at(2024, time.April, 2)},
+		{ItemID: "6", ResolvedURL: "https://Blog.Go.dev/y"},
+	}
+}
+
+func groupIDs(groups map[string][]Item) map[string][]string {
+	ids := make(map[string][]string, len(groups))
+	for key, items := range groups {
+		ids[key] = itemIDs(items)
+	}
+
+	return ids
+}
+
+func TestGroupByDomain(t *testing.T) {
+	groups := GroupByDomain(groupFixture())
+
+	assert.Equal(t, map[string][]string{
+		"example.com": {"1", "2", "4"},
+		"blog.go.dev": {"3", "6"},
+		"":            {"5"},
+	}, groupIDs(groups))
+	assert.Equal(t, []string{"example.com", "blog.go.dev", ""}, GroupKeysBySize(groups))
+}
+
+func TestGroupByMonth(t *testing.T) {
+	groups := GroupByMonth(groupFixture())
+
+	assert.Equal(t, map[string][]string{
+		"2023-12": {"4"},
+		"2024-02": {"3"},
+		"2024-03": {"1", "2"},
+		"2024-04": {"5"},
+		"":        {"6"},
+	}, groupIDs(groups))
+
+	var keys []string
+	for key := range SortedGroups(groups) {
+		keys = append(keys, key)
+	}
+	assert.Equal(t, []string{"", "2023-12", "2024-02", "2024-03", "2024-04"}, keys)
+	assert.Equal(t, []string{"2024-03", "", "2023-12", "2024-02", "2024-04"}, GroupKeysBySize(groups))
+}
+
+func TestSortedGroups_Break(t *testing.T) {
+	var keys []string
+	for key := range SortedGroups(GroupByDomain(groupFixture())) {
+		keys = append(keys, key)
+		break
+	}
+
+	assert.Equal(t, []string{""}, keys)
+}
+
+func TestGroupBy_Empty(t *testing.T) {
+	assert.Empty(t, GroupByDomain(nil))
+	assert.Empty(t, GroupByMonth(nil))
+	assert.Empty(t, GroupKeysBySize(nil))
+}
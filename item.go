@@ -0,0 +1,708 @@
+package pocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ItemStatus is the status code Pocket reports for an item. Codes the SDK
+// does not know are kept verbatim rather than rejected.
+type ItemStatus string
+
+const (
+	ItemStatusUnread   ItemStatus = "0"
+	ItemStatusArchived ItemStatus = "1"
+	ItemStatusDeleted  ItemStatus = "2"
+)
+
+// UnmarshalJSON accepts the status as a JSON string or number.
+func (s *ItemStatus) UnmarshalJSON(b []byte) error {
+	raw := strings.Trim(string(b), `"`)
+	if raw == "null" {
+		raw = ""
+	}
+
+	*s = ItemStatus(raw)
+
+	return nil
+}
+
+// MediaPresence tells apart an item that merely embeds media from one that is
+// the media itself, which Pocket encodes as "1" and "2" respectively.
+type MediaPresence int
+
+const (
+	MediaNone MediaPresence = iota
+	MediaContains
+	MediaIs
+)
+
+// UnmarshalJSON accepts the value as a JSON string or number.
+func (m *MediaPresence) UnmarshalJSON(b []byte) error {
+	n, err := parseStringInt(b)
+	if err != nil {
+		return err
+	}
+
+	if n < int(MediaNone) || n > int(MediaIs) {
+		return fmt.Errorf("unknown media presence %d", n)
+	}
+	*m = MediaPresence(n)
+
+	return nil
+}
+
+// ItemID identifies a saved item. It is a type of its own so that passing a
+// URL where an item ID is expected does not compile.
+type ItemID string
+
+// Item is a single save as returned by the retrieve endpoint. Pocket encodes
+// every scalar as a string, numbers included.
+type Item struct {
+	ItemID        ItemID `json:"item_id"`
+	ResolvedID    string `json:"resolved_id"`
+	GivenURL      string `json:"given_url"`
+	ResolvedURL   string `json:"resolved_url"`
+	GivenTitle    string `json:"given_title"`
+	ResolvedTitle string `json:"resolved_title"`
+	// Excerpt has HTML entities decoded; RawExcerpt is exactly what Pocket
+	// sent.
+	Excerpt     string `json:"excerpt"`
+	RawExcerpt  string `json:"-"`
+	TopImageURL string `json:"top_image_url"`
+	AmpURL      string `json:"amp_url"`
+	// Lang is the detected language code, empty when Pocket could not tell.
+	Lang      string     `json:"lang"`
+	Favorite  int        `json:"favorite,string"`
+	Status    ItemStatus `json:"status"`
+	WordCount int        `json:"word_count"`
+	// TimeToRead is Pocket's own estimate in minutes, 0 when not provided.
+	TimeToRead    int       `json:"time_to_read"`
+	TimeAdded     time.Time `json:"time_added"`
+	TimeUpdated   time.Time `json:"time_updated"`
+	TimeRead      time.Time `json:"time_read"`
+	TimeFavorited time.Time `json:"time_favorited"`
+	SortID        int       `json:"sort_id"`
+
+	IsArticle bool          `json:"is_article"`
+	IsIndex   bool          `json:"is_index"`
+	HasVideo  MediaPresence `json:"has_video"`
+	HasImage  MediaPresence `json:"has_image"`
+
+	// Only filled in when retrieved with DetailComplete.
+	Tags    []string    `json:"tags,omitempty"`
+	Authors []Author    `json:"authors,omitempty"`
+	Image   *ItemImage  `json:"image,omitempty"`
+	Images  []ItemImage `json:"images,omitempty"`
+	Videos  []ItemVideo `json:"videos,omitempty"`
+
+	DomainMetadata *DomainMetadata `json:"domain_metadata,omitempty"`
+
+	// Annotations are the highlights made on the item, when there are any.
+	Annotations []Annotation `json:"annotations,omitempty"`
+}
+
+type Annotation struct {
+	AnnotationID string    `json:"annotation_id"`
+	ItemID       ItemID    `json:"item_id"`
+	Quote        string    `json:"quote"`
+	Patch        string    `json:"patch"`
+	Version      int       `json:"version"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// annotationTimeLayout is how Pocket formats created_at, in UTC.
+const annotationTimeLayout = "2006-01-02 15:04:05"
+
+func (a *Annotation) UnmarshalJSON(b []byte) error {
+	type alias Annotation
+
+	aux := struct {
+		*alias
+		Version   json.RawMessage `json:"version"`
+		CreatedAt string          `json:"created_at"`
+	}{
+		alias: (*alias)(a),
+	}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if a.Version, err = parseStringInt(aux.Version); err != nil {
+		return fmt.Errorf("annotation %s: invalid version %s: %w", a.AnnotationID, aux.Version, err)
+	}
+
+	if a.CreatedAt, err = parseAnnotationTime(aux.CreatedAt); err != nil {
+		return fmt.Errorf("annotation %s: invalid created_at %q: %w", a.AnnotationID, aux.CreatedAt, err)
+	}
+
+	return nil
+}
+
+// parseAnnotationTime accepts Pocket's layout as well as RFC 3339, which is
+// what Annotation marshals back to.
+func parseAnnotationTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(annotationTimeLayout, s); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339, s)
+}
+
+type DomainMetadata struct {
+	Name          string `json:"name"`
+	Logo          string `json:"logo"`
+	GreyscaleLogo string `json:"greyscale_logo"`
+}
+
+type Author struct {
+	ItemID   ItemID `json:"item_id"`
+	AuthorID string `json:"author_id"`
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+}
+
+type ItemImage struct {
+	ItemID  ItemID `json:"item_id"`
+	ImageID string `json:"image_id"`
+	Src     string `json:"src"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+	Credit  string `json:"credit"`
+	Caption string `json:"caption"`
+}
+
+func (m *ItemImage) UnmarshalJSON(b []byte) error {
+	type alias ItemImage
+
+	aux := struct {
+		*alias
+		Width  json.RawMessage `json:"width"`
+		Height json.RawMessage `json:"height"`
+	}{
+		alias: (*alias)(m),
+	}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if m.Width, err = parseStringInt(aux.Width); err != nil {
+		return fmt.Errorf("image %s: invalid width %s: %w", m.ImageID, aux.Width, err)
+	}
+	if m.Height, err = parseStringInt(aux.Height); err != nil {
+		return fmt.Errorf("image %s: invalid height %s: %w", m.ImageID, aux.Height, err)
+	}
+
+	return nil
+}
+
+type ItemVideo struct {
+	ItemID  ItemID `json:"item_id"`
+	VideoID string `json:"video_id"`
+	Src     string `json:"src"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+	Type    string `json:"type"`
+	Vid     string `json:"vid"`
+	// Length is the duration in seconds, 0 when Pocket does not know it.
+	Length int `json:"length"`
+}
+
+func (v *ItemVideo) UnmarshalJSON(b []byte) error {
+	type alias ItemVideo
+
+	aux := struct {
+		*alias
+		Width  json.RawMessage `json:"width"`
+		Height json.RawMessage `json:"height"`
+		Length json.RawMessage `json:"length"`
+	}{
+		alias: (*alias)(v),
+	}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if v.Width, err = parseStringInt(aux.Width); err != nil {
+		return fmt.Errorf("video %s: invalid width %s: %w", v.VideoID, aux.Width, err)
+	}
+	if v.Height, err = parseStringInt(aux.Height); err != nil {
+		return fmt.Errorf("video %s: invalid height %s: %w", v.VideoID, aux.Height, err)
+	}
+	if v.Length, err = parseStringInt(aux.Length); err != nil {
+		return fmt.Errorf("video %s: invalid length %s: %w", v.VideoID, aux.Length, err)
+	}
+
+	return nil
+}
+
+func (i *Item) UnmarshalJSON(b []byte) error {
+	type alias Item
+
+	aux := struct {
+		*alias
+		IsArticle     json.RawMessage `json:"is_article"`
+		IsIndex       json.RawMessage `json:"is_index"`
+		HasVideo      json.RawMessage `json:"has_video"`
+		HasImage      json.RawMessage `json:"has_image"`
+		WordCount     json.RawMessage `json:"word_count"`
+		TimeToRead    json.RawMessage `json:"time_to_read"`
+		TimeAdded     json.RawMessage `json:"time_added"`
+		TimeUpdated   json.RawMessage `json:"time_updated"`
+		TimeRead      json.RawMessage `json:"time_read"`
+		TimeFavorited json.RawMessage `json:"time_favorited"`
+		Tags          json.RawMessage `json:"tags"`
+		Authors       json.RawMessage `json:"authors"`
+		Image         json.RawMessage `json:"image"`
+		Images        json.RawMessage `json:"images"`
+		Videos        json.RawMessage `json:"videos"`
+		Domain        json.RawMessage `json:"domain_metadata"`
+		Annotations   json.RawMessage `json:"annotations"`
+	}{
+		alias: (*alias)(i),
+	}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	times := []struct {
+		field string
+		raw   json.RawMessage
+		dst   *time.Time
+	}{
+		{"time_added", aux.TimeAdded, &i.TimeAdded},
+		{"time_updated", aux.TimeUpdated, &i.TimeUpdated},
+		{"time_read", aux.TimeRead, &i.TimeRead},
+		{"time_favorited", aux.TimeFavorited, &i.TimeFavorited},
+	}
+
+	var err error
+	if i.IsArticle, err = parseStringBool(aux.IsArticle); err != nil {
+		return fmt.Errorf("item %s: invalid is_article value %s: %w", i.ItemID, aux.IsArticle, err)
+	}
+	if i.IsIndex, err = parseStringBool(aux.IsIndex); err != nil {
+		return fmt.Errorf("item %s: invalid is_index value %s: %w", i.ItemID, aux.IsIndex, err)
+	}
+	if err = decodeMedia(aux.HasVideo, &i.HasVideo); err != nil {
+		return fmt.Errorf("item %s: invalid has_video value %s: %w", i.ItemID, aux.HasVideo, err)
+	}
+	if err = decodeMedia(aux.HasImage, &i.HasImage); err != nil {
+		return fmt.Errorf("item %s: invalid has_image value %s: %w", i.ItemID, aux.HasImage, err)
+	}
+	if i.WordCount, err = parseStringInt(aux.WordCount); err != nil {
+		return fmt.Errorf("item %s: invalid word_count value %s: %w", i.ItemID, aux.WordCount, err)
+	}
+	if i.TimeToRead, err = parseStringInt(aux.TimeToRead); err != nil {
+		return fmt.Errorf("item %s: invalid time_to_read value %s: %w", i.ItemID, aux.TimeToRead, err)
+	}
+
+	i.RawExcerpt = i.Excerpt
+	i.Excerpt = html.UnescapeString(i.Excerpt)
+
+	for _, t := range times {
+		parsed, err := parseUnixTime(t.raw)
+		if err != nil {
+			return fmt.Errorf("item %s: invalid %s value %s: %w", i.ItemID, t.field, t.raw, err)
+		}
+		*t.dst = parsed
+	}
+
+	if i.Tags, err = decodeTags(aux.Tags); err != nil {
+		return fmt.Errorf("item %s: invalid tags: %w", i.ItemID, err)
+	}
+	if i.Authors, err = decodeKeyed[Author](aux.Authors); err != nil {
+		return fmt.Errorf("item %s: invalid authors: %w", i.ItemID, err)
+	}
+	if i.Image, err = decodeOptional[ItemImage](aux.Image); err != nil {
+		return fmt.Errorf("item %s: invalid image: %w", i.ItemID, err)
+	}
+	if i.Images, err = decodeKeyed[ItemImage](aux.Images); err != nil {
+		return fmt.Errorf("item %s: invalid images: %w", i.ItemID, err)
+	}
+	if i.Videos, err = decodeKeyed[ItemVideo](aux.Videos); err != nil {
+		return fmt.Errorf("item %s: invalid videos: %w", i.ItemID, err)
+	}
+	if i.DomainMetadata, err = decodeOptional[DomainMetadata](aux.Domain); err != nil {
+		return fmt.Errorf("item %s: invalid domain_metadata: %w", i.ItemID, err)
+	}
+	if i.Annotations, err = decodeKeyed[Annotation](aux.Annotations); err != nil {
+		return fmt.Errorf("item %s: invalid annotations: %w", i.ItemID, err)
+	}
+
+	return nil
+}
+
+func (i Item) IsUnread() bool {
+	return i.Status == ItemStatusUnread
+}
+
+func (i Item) IsArchived() bool {
+	return i.Status == ItemStatusArchived
+}
+
+// IsDeleted reports whether Pocket flagged the item for deletion, which only
+// shows up in since-based retrieves.
+func (i Item) IsDeleted() bool {
+	return i.Status == ItemStatusDeleted
+}
+
+// BestURL picks the URL to link to: resolved_url when it is an absolute
+// http(s) URL, given_url otherwise. Both raw values stay available on the
+// item.
+func (i Item) BestURL() string {
+	if isHTTPURL(i.ResolvedURL) {
+		return i.ResolvedURL
+	}
+
+	return i.GivenURL
+}
+
+func isHTTPURL(rawurl string) bool {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// AddedItem is what Pocket reports about a URL it has just saved, through
+// Add or an add action. Resolution may still be pending, in which case only
+// the IDs and URLs are set.
+type AddedItem struct {
+	ItemID      ItemID        `json:"item_id"`
+	ResolvedID  string        `json:"resolved_id"`
+	GivenURL    string        `json:"given_url"`
+	NormalURL   string        `json:"normal_url"`
+	ResolvedURL string        `json:"resolved_url"`
+	Title       string        `json:"title"`
+	Excerpt     string        `json:"excerpt"`
+	Lang        string        `json:"lang"`
+	WordCount   int           `json:"-"`
+	IsArticle   bool          `json:"-"`
+	IsIndex     bool          `json:"-"`
+	HasImage    MediaPresence `json:"-"`
+	HasVideo    MediaPresence `json:"-"`
+}
+
+func (a *AddedItem) UnmarshalJSON(b []byte) error {
+	type alias AddedItem
+
+	aux := struct {
+		*alias
+		WordCount json.RawMessage `json:"word_count"`
+		IsArticle json.RawMessage `json:"is_article"`
+		IsIndex   json.RawMessage `json:"is_index"`
+		HasImage  json.RawMessage `json:"has_image"`
+		HasVideo  json.RawMessage `json:"has_video"`
+	}{
+		alias: (*alias)(a),
+	}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	if a.WordCount, err = parseStringInt(aux.WordCount); err != nil {
+		return fmt.Errorf("item %s: invalid word_count value %s: %w", a.ItemID, aux.WordCount, err)
+	}
+	if a.IsArticle, err = parseStringBool(aux.IsArticle); err != nil {
+		return fmt.Errorf("item %s: invalid is_article value %s: %w", a.ItemID, aux.IsArticle, err)
+	}
+	if a.IsIndex, err = parseStringBool(aux.IsIndex); err != nil {
+		return fmt.Errorf("item %s: invalid is_index value %s: %w", a.ItemID, aux.IsIndex, err)
+	}
+	if err = decodeMedia(aux.HasImage, &a.HasImage); err != nil {
+		return fmt.Errorf("item %s: invalid has_image value %s: %w", a.ItemID, aux.HasImage, err)
+	}
+	if err = decodeMedia(aux.HasVideo, &a.HasVideo); err != nil {
+		return fmt.Errorf("item %s: invalid has_video value %s: %w", a.ItemID, aux.HasVideo, err)
+	}
+
+	a.Excerpt = html.UnescapeString(a.Excerpt)
+
+	return nil
+}
+
+// CanonicalURL is like BestURL but avoids AMP: the amp_url Pocket reports is
+// skipped in favour of the other URL, and Google AMP viewer and AMP cache
+// wrappers are unwrapped to the publisher's URL.
+func (i Item) CanonicalURL() string {
+	for _, candidate := range []string{i.ResolvedURL, i.GivenURL} {
+		if !isHTTPURL(candidate) {
+			continue
+		}
+
+		if unwrapped, ok := unwrapAMP(candidate); ok {
+			return unwrapped
+		}
+
+		if i.AmpURL != "" && candidate == i.AmpURL {
+			continue
+		}
+
+		return candidate
+	}
+
+	return i.BestURL()
+}
+
+// unwrapAMP extracts the publisher URL from the Google AMP viewer
+// (google.com/amp/s/host/path) and AMP cache (host.cdn.ampproject.org/c/s/
+// host/path) shapes. The "s" segment marks an https origin.
+func unwrapAMP(rawurl string) (string, bool) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return "", false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	path := u.EscapedPath()
+
+	var rest string
+	switch {
+	case (host == "google.com" || strings.HasSuffix(host, ".google.com")) && strings.HasPrefix(path, "/amp/"):
+		rest = strings.TrimPrefix(path, "/amp/")
+	case strings.HasSuffix(host, ".cdn.ampproject.org"):
+		rest = strings.TrimPrefix(path, "/")
+		for _, prefix := range []string{"c/", "v/", "i/"} {
+			rest = strings.TrimPrefix(rest, prefix)
+		}
+	default:
+		return "", false
+	}
+
+	scheme := "http://"
+	if strings.HasPrefix(rest, "s/") {
+		scheme = "https://"
+		rest = strings.TrimPrefix(rest, "s/")
+	}
+
+	if rest == "" {
+		return "", false
+	}
+
+	unwrapped := scheme + rest
+	if u.RawQuery != "" {
+		unwrapped += "?" + u.RawQuery
+	}
+
+	if !isHTTPURL(unwrapped) {
+		return "", false
+	}
+
+	return unwrapped, true
+}
+
+// Domain is the normalized host the item was saved from: resolved_url is
+// preferred over given_url, the host is lowercased and "www." and any port
+// are dropped. It is empty when neither URL has a host.
+func (i Item) Domain() string {
+	for _, raw := range []string{i.ResolvedURL, i.GivenURL} {
+		if host := hostOf(raw); host != "" {
+			return host
+		}
+	}
+
+	return ""
+}
+
+func hostOf(rawurl string) string {
+	u, err := url.Parse(strings.TrimSpace(rawurl))
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// defaultWordsPerMinute is used by ReadingTime for a non-positive rate.
+const defaultWordsPerMinute = 200
+
+// ReadingTime estimates how long the item takes to read. Pocket's own
+// time_to_read wins when present; otherwise it is derived from the word
+// count at wordsPerMinute, falling back to 200 for a non-positive rate.
+func (i Item) ReadingTime(wordsPerMinute int) time.Duration {
+	if i.TimeToRead > 0 {
+		return time.Duration(i.TimeToRead) * time.Minute
+	}
+
+	if wordsPerMinute <= 0 {
+		wordsPerMinute = defaultWordsPerMinute
+	}
+
+	return time.Duration(i.WordCount) * time.Minute / time.Duration(wordsPerMinute)
+}
+
+// decodeTags flattens the tags object, keyed by tag name, into a sorted slice
+// matching the AddInput.Tags representation.
+func decodeTags(raw json.RawMessage) ([]string, error) {
+	type tag struct {
+		Tag string `json:"tag"`
+	}
+
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" || string(raw) == "[]" {
+		return nil, nil
+	}
+
+	var keyed map[string]tag
+	if err := json.Unmarshal(raw, &keyed); err != nil {
+		return nil, err
+	}
+	if len(keyed) == 0 {
+		return nil, nil
+	}
+
+	tags := make([]string, 0, len(keyed))
+	for name, t := range keyed {
+		if t.Tag != "" {
+			name = t.Tag
+		}
+		tags = append(tags, name)
+	}
+	sort.Strings(tags)
+
+	return tags, nil
+}
+
+// decodeOptional decodes a nested object that Pocket may leave out or send as
+// null, {} or []; all of those decode to nil.
+func decodeOptional[T any](raw json.RawMessage) (*T, error) {
+	raw = bytes.TrimSpace(raw)
+	switch string(raw) {
+	case "", "null", "{}", "[]":
+		return nil, nil
+	}
+
+	var v T
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// decodeKeyed decodes the object-keyed collections Pocket uses for nested
+// item data into a slice ordered by key. Pocket sends an empty array instead
+// of an empty object, so missing, null, [] and {} all decode to nil.
+func decodeKeyed[T any](raw json.RawMessage) ([]T, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	if raw[0] == '[' {
+		var list []T
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return nil, err
+		}
+		if len(list) == 0 {
+			return nil, nil
+		}
+		return list, nil
+	}
+
+	var keyed map[string]T
+	if err := json.Unmarshal(raw, &keyed); err != nil {
+		return nil, err
+	}
+	if len(keyed) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(keyed))
+	for k := range keyed {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		return lessKey(keys[a], keys[b])
+	})
+
+	list := make([]T, 0, len(keys))
+	for _, k := range keys {
+		list = append(list, keyed[k])
+	}
+
+	return list, nil
+}
+
+// parseStringInt decodes an integer sent either as a JSON string or number.
+// Missing values, null and "" yield 0.
+func parseStringInt(raw json.RawMessage) (int, error) {
+	s := strings.Trim(string(raw), `"`)
+	if s == "" || s == "null" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(s)
+}
+
+// parseStringBool decodes a "0"/"1" flag, also accepting JSON numbers and
+// booleans. Missing values and null yield false.
+func parseStringBool(raw json.RawMessage) (bool, error) {
+	s := strings.Trim(string(raw), `"`)
+	switch s {
+	case "", "null", "0", "false":
+		return false, nil
+	case "1", "true":
+		return true, nil
+	}
+
+	return false, fmt.Errorf("unknown flag %q", s)
+}
+
+func decodeMedia(raw json.RawMessage, dst *MediaPresence) error {
+	if len(raw) == 0 {
+		*dst = MediaNone
+		return nil
+	}
+
+	return dst.UnmarshalJSON(raw)
+}
+
+// lessKey orders numeric keys numerically and everything else lexically.
+func lessKey(a, b string) bool {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	if errA == nil && errB == nil {
+		return na < nb
+	}
+
+	return a < b
+}
+
+// parseUnixTime decodes unix seconds sent either as a JSON string or number.
+// Missing values, null and "0" all mean unset and yield the zero time.
+func parseUnixTime(raw json.RawMessage) (time.Time, error) {
+	s := strings.Trim(string(raw), `"`)
+	if s == "" || s == "0" || s == "null" {
+		return time.Time{}, nil
+	}
+
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(sec, 0).UTC(), nil
+}
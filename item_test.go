@@ -0,0 +1,945 @@
+package pocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const itemFixture = `{
+	"item_id": "229279689",
+	"resolved_id": "229279689",
+	"given_url": "http://www.grantland.com/blog/the-triangle/post/_/id/38347/ryder-cup-preview",
+	"given_title": "The Massive Ryder Cup Preview - The Triangle Blog - Grantland",
+	"favorite": "1",
+	"status": "0",
+	"time_added": "1471869712",
+	"time_updated": "1471869712",
+	"time_read": "0",
+	"time_favorited": "1471869720",
+	"sort_id": 3,
+	"resolved_title": "The Massive Ryder Cup Preview",
+	"resolved_url": "http://www.grantland.com/blog/the-triangle/post/_/id/38347/ryder-cup-preview",
+	"excerpt": "The list of things I love about the Ryder Cup is so long that it could fill a (tedious) novel, and golf fans can probably guess most of them.",
+	"is_article": "1",
+	"is_index": "0",
+	"has_video": "1",
+	"has_image": "1",
+	"word_count": "3197",
+	"lang": "en",
+	"time_to_read": 15,
+	"top_image_url": "https://s3.amazonaws.com/pocket-syndication/preview.jpg",
+	"listen_duration_estimate": 1238
+}`
+
+func TestItem_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    Item
+		wantErr bool
+	}{
+		{
+			name:    "Captured-OK",
+			payload: itemFixture,
+			want: Item{
+				ItemID:        "229279689",
+				ResolvedID:    "229279689",
+				GivenURL:      "http://www.grantland.com/blog/the-triangle/post/_/id/38347/ryder-cup-preview",
+				ResolvedURL:   "http://www.grantland.com/blog/the-triangle/post/_/id/38347/ryder-cup-preview",
+				GivenTitle:    "The Massive Ryder Cup Preview - The Triangle Blog - Grantland",
+				ResolvedTitle: "The Massive Ryder Cup Preview",
+				Excerpt:       "The list of things I love about the Ryder Cup is so long that it could fill a (tedious) novel, and golf fans can probably guess most of them.",
+				RawExcerpt:    "The list of things I love about the Ryder Cup is so long that it could fill a (tedious) novel, and golf fans can probably guess most of them.",
+				TopImageURL:   "https://s3.amazonaws.com/pocket-syndication/preview.jpg",
+				Lang:          "en",
+				Favorite:      1,
+				Status:        ItemStatusUnread,
+				WordCount:     3197,
+				TimeToRead:    15,
+				TimeAdded:     time.Unix(1471869712, 0).UTC(),
+				TimeUpdated:   time.Unix(1471869712, 0).UTC(),
+				TimeFavorited: time.Unix(1471869720, 0).UTC(),
+				SortID:        3,
+				IsArticle:     true,
+				HasVideo:      MediaContains,
+				HasImage:      MediaContains,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Archived-OK",
+			payload: `{"item_id":"1","status":"1","word_count":"0"}`,
+			want: Item{
+				ItemID: "1",
+				Status: ItemStatusArchived,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Missing times-OK",
+			payload: `{"item_id":"2","time_added":"1471869712"}`,
+			want: Item{
+				ItemID:    "2",
+				TimeAdded: time.Unix(1471869712, 0).UTC(),
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Numeric time-OK",
+			payload: `{"item_id":"3","time_updated":1471869712}`,
+			want: Item{
+				ItemID:      "3",
+				TimeUpdated: time.Unix(1471869712, 0).UTC(),
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Malformed time",
+			payload: `{"item_id":"4","time_read":"yesterday"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Malformed word count",
+			payload: `{"item_id":"1","word_count":"many"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Item
+
+			err := json.Unmarshal([]byte(tt.payload), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestItem_UnmarshalJSON_TimeError(t *testing.T) {
+	var got Item
+
+	err := json.Unmarshal([]byte(`{"item_id":"42","time_favorited":"soon"}`), &got)
+	assert.ErrorContains(t, err, "item 42")
+	assert.ErrorContains(t, err, "time_favorited")
+}
+
+func TestItem_UnmarshalJSON_Complete(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    Item
+		wantErr bool
+	}{
+		{
+			name: "Complete-OK",
+			payload: `{"item_id":"229279689",` +
+				`"tags":{"golang":{"item_id":"229279689","tag":"golang"},"backlog":{"item_id":"229279689","tag":"backlog"}},` +
+				`"authors":{"62344":{"item_id":"229279689","author_id":"62344","name":"Bill Barnwell","url":"http://www.grantland.com/contributors/_/name/bill-barnwell"}},` +
+				`"images":{"1":{"item_id":"229279689","image_id":"1","src":"http://a.espncdn.com/photo/2012/0927/grant_g_ryder_cr_640.jpg","width":"0","height":"0","credit":"Getty Images","caption":""}},` +
+				`"videos":{"1":{"item_id":"229279689","video_id":"1","src":"http://www.youtube.com/v/Er34PbFkVGk","width":"420","height":"315","type":"1","vid":"Er34PbFkVGk","length":"0"}}}`,
+			want: Item{
+				ItemID: "229279689",
+				Tags:   []string{"backlog", "golang"},
+				Authors: []Author{
+					{ItemID: "229279689", AuthorID: "62344", Name: "Bill Barnwell", URL: "http://www.grantland.com/contributors/_/name/bill-barnwell"},
+				},
+				Images: []ItemImage{
+					{ItemID: "229279689", ImageID: "1", Src: "http://a.espncdn.com/photo/2012/0927/grant_g_ryder_cr_640.jpg", Credit: "Getty Images"},
+				},
+				Videos: []ItemVideo{
+					{ItemID: "229279689", VideoID: "1", Src: "http://www.youtube.com/v/Er34PbFkVGk", Width: 420, Height: 315, Type: "1", Vid: "Er34PbFkVGk"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Simple-OK",
+			payload: `{"item_id":"229279689"}`,
+			want:    Item{ItemID: "229279689"},
+			wantErr: false,
+		},
+		{
+			name:    "Empty collections-OK",
+			payload: `{"item_id":"229279689","tags":[],"authors":{},"images":null,"videos":[]}`,
+			want:    Item{ItemID: "229279689"},
+			wantErr: false,
+		},
+		{
+			name:    "Malformed tags",
+			payload: `{"item_id":"229279689","tags":"golang"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Item
+
+			err := json.Unmarshal([]byte(tt.payload), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestItem_UnmarshalJSON_Images(t *testing.T) {
+	tests := []struct {
+		name       string
+		payload    string
+		wantImage  *ItemImage
+		wantImages []ItemImage
+		wantErr    bool
+	}{
+		{
+			name: "Primary and keyed images",
+			payload: `{"item_id":"229279689",` +
+				`"image":{"item_id":"229279689","src":"http://a.espncdn.com/photo/2012/0927/grant_g_ryder_cr_640.jpg","width":"640","height":"360"},` +
+				`"images":{` +
+				`"2":{"item_id":"229279689","image_id":"2","src":"http://a.espncdn.com/photo/2012/0927/second.jpg","width":"1024","height":"768","credit":"","caption":"Second"},` +
+				`"1":{"item_id":"229279689","image_id":"1","src":"http://a.espncdn.com/photo/2012/0927/grant_g_ryder_cr_640.jpg","width":"640","height":"360","credit":"Getty Images","caption":"The Ryder Cup"},` +
+				`"10":{"item_id":"229279689","image_id":"10","src":"http://a.espncdn.com/photo/2012/0927/tenth.jpg","width":"","height":"","credit":"","caption":""}` +
+				`}}`,
+			wantImage: &ItemImage{
+				ItemID: "229279689",
+				Src:    "http://a.espncdn.com/photo/2012/0927/grant_g_ryder_cr_640.jpg",
+				Width:  640,
+				Height: 360,
+			},
+			wantImages: []ItemImage{
+				{ItemID: "229279689", ImageID: "1", Src: "http://a.espncdn.com/photo/2012/0927/grant_g_ryder_cr_640.jpg", Width: 640, Height: 360, Credit: "Getty Images", Caption: "The Ryder Cup"},
+				{ItemID: "229279689", ImageID: "2", Src: "http://a.espncdn.com/photo/2012/0927/second.jpg", Width: 1024, Height: 768, Caption: "Second"},
+				{ItemID: "229279689", ImageID: "10", Src: "http://a.espncdn.com/photo/2012/0927/tenth.jpg"},
+			},
+		},
+		{
+			name:    "Missing images",
+			payload: `{"item_id":"229279689"}`,
+		},
+		{
+			name:    "Empty image objects",
+			payload: `{"item_id":"229279689","image":{},"images":{}}`,
+		},
+		{
+			name:    "Empty image arrays",
+			payload: `{"item_id":"229279689","image":[],"images":[]}`,
+		},
+		{
+			name:    "Malformed width",
+			payload: `{"item_id":"229279689","images":{"1":{"image_id":"1","width":"wide"}}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Item
+
+			err := json.Unmarshal([]byte(tt.payload), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantImage, got.Image)
+				assert.Equal(t, tt.wantImages, got.Images)
+			}
+		})
+	}
+}
+
+func TestItem_UnmarshalJSON_Videos(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    []ItemVideo
+		wantErr bool
+	}{
+		{
+			name: "Keyed videos",
+			payload: `{"item_id":"229279689","has_video":"1","videos":{` +
+				`"1":{"item_id":"229279689","video_id":"1","src":"http://www.youtube.com/v/Er34PbFkVGk","width":"420","height":"315","type":"1","vid":"Er34PbFkVGk","length":"212"},` +
+				`"2":{"item_id":"229279689","video_id":"2","src":"https://player.vimeo.com/video/1234","width":"0","height":"0","type":"2","vid":"1234","length":""}` +
+				`}}`,
+			want: []ItemVideo{
+				{ItemID: "229279689", VideoID: "1", Src: "http://www.youtube.com/v/Er34PbFkVGk", Width: 420, Height: 315, Type: "1", Vid: "Er34PbFkVGk", Length: 212},
+				{ItemID: "229279689", VideoID: "2", Src: "https://player.vimeo.com/video/1234", Type: "2", Vid: "1234"},
+			},
+		},
+		{
+			name: "Numeric encoding",
+			payload: `{"item_id":"229279689","videos":{` +
+				`"1":{"item_id":"229279689","video_id":"1","width":420,"height":315,"length":212}` +
+				`}}`,
+			want: []ItemVideo{
+				{ItemID: "229279689", VideoID: "1", Width: 420, Height: 315, Length: 212},
+			},
+		},
+		{
+			name:    "has_video without videos",
+			payload: `{"item_id":"229279689","has_video":"1"}`,
+		},
+		{
+			name:    "has_video with empty videos",
+			payload: `{"item_id":"229279689","has_video":"2","videos":[]}`,
+		},
+		{
+			name:    "Malformed length",
+			payload: `{"item_id":"229279689","videos":{"1":{"video_id":"1","length":"3:32"}}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Item
+
+			err := json.Unmarshal([]byte(tt.payload), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got.Videos)
+			}
+		})
+	}
+}
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// assertGolden compares got, encoded as indented JSON, with the named golden
+// file in testdata. Run the tests with -update to rewrite it.
+func assertGolden(t *testing.T, name string, got interface{}) {
+	b, err := json.MarshalIndent(got, "", "  ")
+	assert.NoError(t, err)
+	b = append(b, '\n')
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		assert.NoError(t, os.WriteFile(path, b, 0o644))
+	}
+
+	want, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, string(want), string(b))
+}
+
+func TestItem_UnmarshalJSON_AuthorsGolden(t *testing.T) {
+	payload, err := os.ReadFile(filepath.Join("testdata", "get_complete.json"))
+	assert.NoError(t, err)
+
+	authors := map[string][]Author{}
+	_, err = decodeGetResponse(bytes.NewReader(payload), func(item Item) error {
+		authors[string(item.ItemID)] = item.Authors
+		return nil
+	})
+	assert.NoError(t, err)
+
+	assertGolden(t, "get_complete.authors.golden", authors)
+}
+
+func TestItem_UnmarshalJSON_Authors(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    []Author
+		wantErr bool
+	}{
+		{
+			name:    "Missing authors",
+			payload: `{"item_id":"1"}`,
+		},
+		{
+			name:    "Empty object",
+			payload: `{"item_id":"1","authors":{}}`,
+		},
+		{
+			name:    "Empty array",
+			payload: `{"item_id":"1","authors":[]}`,
+		},
+		{
+			name:    "Array of authors",
+			payload: `{"item_id":"1","authors":[{"item_id":"1","author_id":"7","name":"Rob Pike","url":""}]}`,
+			want:    []Author{{ItemID: "1", AuthorID: "7", Name: "Rob Pike"}},
+		},
+		{
+			name:    "Unexpected string",
+			payload: `{"item_id":"1","authors":"Rob Pike"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Item
+
+			err := json.Unmarshal([]byte(tt.payload), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got.Authors)
+			}
+		})
+	}
+}
+
+func TestItem_UnmarshalJSON_Tags(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Keyed tags sorted",
+			payload: `{"item_id":"1","tags":{` +
+				`"zig":{"item_id":"1","tag":"zig"},` +
+				`"go":{"item_id":"1","tag":"go"},` +
+				`"чтение":{"item_id":"1","tag":"чтение"},` +
+				`"Later":{"item_id":"1","tag":"Later"}}}`,
+			want: []string{"Later", "go", "zig", "чтение"},
+		},
+		{
+			name:    "Key without nested tag",
+			payload: `{"item_id":"1","tags":{"go":{"item_id":"1"}}}`,
+			want:    []string{"go"},
+		},
+		{
+			name:    "Simple mode",
+			payload: `{"item_id":"1"}`,
+		},
+		{
+			name:    "Empty array",
+			payload: `{"item_id":"1","tags":[]}`,
+		},
+		{
+			name:    "Malformed tags",
+			payload: `{"item_id":"1","tags":["go"]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Item
+
+			err := json.Unmarshal([]byte(tt.payload), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got.Tags)
+			}
+		})
+	}
+}
+
+func TestItem_UnmarshalJSON_DomainMetadata(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    *DomainMetadata
+		wantErr bool
+	}{
+		{
+			name: "Full metadata",
+			payload: `{"item_id":"1","domain_metadata":{"name":"Grantland",` +
+				`"logo":"https://logo.clearbit.com/grantland.com?size=800",` +
+				`"greyscale_logo":"https://logo.clearbit.com/grantland.com?size=800&greyscale=true"}}`,
+			want: &DomainMetadata{
+				Name:          "Grantland",
+				Logo:          "https://logo.clearbit.com/grantland.com?size=800",
+				GreyscaleLogo: "https://logo.clearbit.com/grantland.com?size=800&greyscale=true",
+			},
+		},
+		{
+			name:    "Name only",
+			payload: `{"item_id":"1","domain_metadata":{"name":"LWN.net"}}`,
+			want:    &DomainMetadata{Name: "LWN.net"},
+		},
+		{
+			name:    "Absent",
+			payload: `{"item_id":"1"}`,
+		},
+		{
+			name:    "Null",
+			payload: `{"item_id":"1","domain_metadata":null}`,
+		},
+		{
+			name:    "Malformed",
+			payload: `{"item_id":"1","domain_metadata":"Grantland"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Item
+
+			err := json.Unmarshal([]byte(tt.payload), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got.DomainMetadata)
+			}
+		})
+	}
+}
+
+func TestItem_UnmarshalJSON_Excerpt(t *testing.T) {
+	tests := []struct {
+		name       string
+		payload    string
+		want       string
+		wantRaw    string
+		wantTopImg string
+	}{
+		{
+			name:    "Entities decoded",
+			payload: `{"item_id":"1","excerpt":"Tom &amp; Jerry &quot;return&quot; &#8212; again&#x2026;"}`,
+			want:    `Tom & Jerry "return" — again…`,
+			wantRaw: `Tom &amp; Jerry &quot;return&quot; &#8212; again&#x2026;`,
+		},
+		{
+			name:    "Non-ASCII text",
+			payload: `{"item_id":"1","excerpt":"Горутины &laquo;дешёвые&raquo;, но не бесплатные 🚀"}`,
+			want:    `Горутины «дешёвые», но не бесплатные 🚀`,
+			wantRaw: `Горутины &laquo;дешёвые&raquo;, но не бесплатные 🚀`,
+		},
+		{
+			name:       "Top image",
+			payload:    `{"item_id":"1","excerpt":"","top_image_url":"https://example.com/a.png?w=1&amp;h=2"}`,
+			wantTopImg: "https://example.com/a.png?w=1&amp;h=2",
+		},
+		{
+			name:    "Absent",
+			payload: `{"item_id":"1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Item
+
+			assert.NoError(t, json.Unmarshal([]byte(tt.payload), &got))
+			assert.Equal(t, tt.want, got.Excerpt)
+			assert.Equal(t, tt.wantRaw, got.RawExcerpt)
+			assert.Equal(t, tt.wantTopImg, got.TopImageURL)
+		})
+	}
+}
+
+func TestItem_UnmarshalJSON_ReadingFields(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        string
+		wantWordCount  int
+		wantTimeToRead int
+		wantErr        bool
+	}{
+		{
+			name:           "String encoded",
+			payload:        `{"item_id":"1","word_count":"3197","time_to_read":"15"}`,
+			wantWordCount:  3197,
+			wantTimeToRead: 15,
+		},
+		{
+			name:           "Number encoded",
+			payload:        `{"item_id":"1","word_count":3197,"time_to_read":15}`,
+			wantWordCount:  3197,
+			wantTimeToRead: 15,
+		},
+		{
+			name:    "Absent",
+			payload: `{"item_id":"1"}`,
+		},
+		{
+			name:    "Malformed time_to_read",
+			payload: `{"item_id":"1","time_to_read":"quick"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Item
+
+			err := json.Unmarshal([]byte(tt.payload), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantWordCount, got.WordCount)
+				assert.Equal(t, tt.wantTimeToRead, got.TimeToRead)
+			}
+		})
+	}
+}
+
+func TestItem_ReadingTime(t *testing.T) {
+	tests := []struct {
+		name           string
+		item           Item
+		wordsPerMinute int
+		want           time.Duration
+	}{
+		{
+			name:           "Prefers time_to_read",
+			item:           Item{WordCount: 3197, TimeToRead: 15},
+			wordsPerMinute: 100,
+			want:           15 * time.Minute,
+		},
+		{
+			name:           "Falls back to word count",
+			item:           Item{WordCount: 3000},
+			wordsPerMinute: 250,
+			want:           12 * time.Minute,
+		},
+		{
+			name:           "Fractional minutes",
+			item:           Item{WordCount: 300},
+			wordsPerMinute: 200,
+			want:           90 * time.Second,
+		},
+		{
+			name:           "Default rate",
+			item:           Item{WordCount: 1000},
+			wordsPerMinute: 0,
+			want:           5 * time.Minute,
+		},
+		{
+			name:           "Nothing known",
+			item:           Item{},
+			wordsPerMinute: 200,
+			want:           0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.item.ReadingTime(tt.wordsPerMinute))
+		})
+	}
+}
+
+func TestItem_Status(t *testing.T) {
+	tests := []struct {
+		name         string
+		payload      string
+		want         ItemStatus
+		wantUnread   bool
+		wantArchived bool
+		wantDeleted  bool
+	}{
+		{
+			name:       "Unread",
+			payload:    `{"item_id":"1","status":"0"}`,
+			want:       ItemStatusUnread,
+			wantUnread: true,
+		},
+		{
+			name:         "Archived",
+			payload:      `{"item_id":"1","status":"1"}`,
+			want:         ItemStatusArchived,
+			wantArchived: true,
+		},
+		{
+			name:        "Deleted",
+			payload:     `{"item_id":"1","status":"2"}`,
+			want:        ItemStatusDeleted,
+			wantDeleted: true,
+		},
+		{
+			name:        "Numeric encoding",
+			payload:     `{"item_id":"1","status":2}`,
+			want:        ItemStatusDeleted,
+			wantDeleted: true,
+		},
+		{
+			name:    "Unknown value preserved",
+			payload: `{"item_id":"1","status":"3"}`,
+			want:    ItemStatus("3"),
+		},
+		{
+			name:    "Absent",
+			payload: `{"item_id":"1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Item
+
+			assert.NoError(t, json.Unmarshal([]byte(tt.payload), &got))
+			assert.Equal(t, tt.want, got.Status)
+			assert.Equal(t, tt.wantUnread, got.IsUnread())
+			assert.Equal(t, tt.wantArchived, got.IsArchived())
+			assert.Equal(t, tt.wantDeleted, got.IsDeleted())
+		})
+	}
+}
+
+func TestItem_UnmarshalJSON_MediaFlags(t *testing.T) {
+	tests := []struct {
+		name          string
+		payload       string
+		wantIsArticle bool
+		wantIsIndex   bool
+		wantHasVideo  MediaPresence
+		wantHasImage  MediaPresence
+		wantErr       bool
+	}{
+		{
+			name:          "Article with embedded media",
+			payload:       `{"item_id":"1","is_article":"1","is_index":"0","has_video":"1","has_image":"1"}`,
+			wantIsArticle: true,
+			wantHasVideo:  MediaContains,
+			wantHasImage:  MediaContains,
+		},
+		{
+			name:         "Item is a video",
+			payload:      `{"item_id":"1","is_article":"0","is_index":"0","has_video":"2","has_image":"0"}`,
+			wantHasVideo: MediaIs,
+			wantHasImage: MediaNone,
+		},
+		{
+			name:         "Item is an image",
+			payload:      `{"item_id":"1","is_article":"0","has_video":"0","has_image":"2"}`,
+			wantHasImage: MediaIs,
+		},
+		{
+			name:        "Index page",
+			payload:     `{"item_id":"1","is_index":"1"}`,
+			wantIsIndex: true,
+		},
+		{
+			name:    "Absent",
+			payload: `{"item_id":"1"}`,
+		},
+		{
+			name:    "Unknown has_video",
+			payload: `{"item_id":"1","has_video":"3"}`,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown is_article",
+			payload: `{"item_id":"1","is_article":"yes"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Item
+
+			err := json.Unmarshal([]byte(tt.payload), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantIsArticle, got.IsArticle)
+				assert.Equal(t, tt.wantIsIndex, got.IsIndex)
+				assert.Equal(t, tt.wantHasVideo, got.HasVideo)
+				assert.Equal(t, tt.wantHasImage, got.HasImage)
+			}
+		})
+	}
+}
+
+func TestItem_Domain(t *testing.T) {
+	tests := []struct {
+		name string
+		item Item
+		want string
+	}{
+		{name: "Resolved preferred", item: Item{GivenURL: "https://t.co/abc", ResolvedURL: "https://lwn.net/Articles/1/"}, want: "lwn.net"},
+		{name: "Given fallback", item: Item{GivenURL: "https://lwn.net/Articles/1/"}, want: "lwn.net"},
+		{name: "Invalid resolved", item: Item{GivenURL: "https://lwn.net/", ResolvedURL: "http://[::1"}, want: "lwn.net"},
+		{name: "WWW and case", item: Item{ResolvedURL: "https://WWW.Example.COM/path"}, want: "example.com"},
+		{name: "Port dropped", item: Item{ResolvedURL: "http://example.com:8080/path"}, want: "example.com"},
+		{name: "IPv4 literal", item: Item{ResolvedURL: "http://192.168.0.1:8080/"}, want: "192.168.0.1"},
+		{name: "IPv6 literal", item: Item{ResolvedURL: "http://[2001:db8::1]:8080/"}, want: "2001:db8::1"},
+		{name: "Relative", item: Item{GivenURL: "/just/a/path"}, want: ""},
+		{name: "Empty", item: Item{}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.item.Domain())
+		})
+	}
+}
+
+func TestItem_UnmarshalJSON_Annotations(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    []Annotation
+		wantErr bool
+	}{
+		{
+			name: "Highlights",
+			payload: `{"item_id":"229279689","annotations":[` +
+				`{"annotation_id":"a1b2","item_id":"229279689","quote":"golf fans can probably guess most of them","patch":"@@ -1 +1 @@","version":"2","created_at":"2019-06-19 19:09:25"},` +
+				`{"annotation_id":"c3d4","item_id":"229279689","quote":"Ryder Cup","patch":"","version":2,"created_at":"2019-06-20T08:00:00Z"}` +
+				`]}`,
+			want: []Annotation{
+				{AnnotationID: "a1b2", ItemID: "229279689", Quote: "golf fans can probably guess most of them", Patch: "@@ -1 +1 @@", Version: 2, CreatedAt: time.Date(2019, 6, 19, 19, 9, 25, 0, time.UTC)},
+				{AnnotationID: "c3d4", ItemID: "229279689", Quote: "Ryder Cup", Version: 2, CreatedAt: time.Date(2019, 6, 20, 8, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			name:    "No highlights",
+			payload: `{"item_id":"229279689","annotations":[]}`,
+		},
+		{
+			name:    "Absent",
+			payload: `{"item_id":"229279689"}`,
+		},
+		{
+			name:    "Malformed created_at",
+			payload: `{"item_id":"229279689","annotations":[{"annotation_id":"a1b2","created_at":"yesterday"}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Item
+
+			err := json.Unmarshal([]byte(tt.payload), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got.Annotations)
+			}
+		})
+	}
+}
+
+func TestItem_BestURL(t *testing.T) {
+	tests := []struct {
+		name string
+		item Item
+		want string
+	}{
+		{
+			name: "Resolved preferred",
+			item: Item{GivenURL: "https://t.co/abc", ResolvedURL: "https://lwn.net/Articles/1/"},
+			want: "https://lwn.net/Articles/1/",
+		},
+		{
+			name: "Empty resolved",
+			item: Item{GivenURL: "https://lwn.net/Articles/1/"},
+			want: "https://lwn.net/Articles/1/",
+		},
+		{
+			name: "Relative resolved",
+			item: Item{GivenURL: "https://lwn.net/Articles/1/", ResolvedURL: "/Articles/1/"},
+			want: "https://lwn.net/Articles/1/",
+		},
+		{
+			name: "Non-http resolved",
+			item: Item{GivenURL: "https://example.com/paper.pdf", ResolvedURL: "ftp://example.com/paper.pdf"},
+			want: "https://example.com/paper.pdf",
+		},
+		{
+			name: "Scheme without host",
+			item: Item{GivenURL: "https://example.com/", ResolvedURL: "https:///nohost"},
+			want: "https://example.com/",
+		},
+		{
+			name: "Garbage resolved",
+			item: Item{GivenURL: "https://example.com/", ResolvedURL: "http://[::1"},
+			want: "https://example.com/",
+		},
+		{
+			name: "Both empty",
+			item: Item{},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.item.BestURL())
+		})
+	}
+}
+
+func TestItem_CanonicalURL(t *testing.T) {
+	tests := []struct {
+		name string
+		item Item
+		want string
+	}{
+		{
+			name: "Plain article",
+			item: Item{GivenURL: "https://t.co/x", ResolvedURL: "https://www.theguardian.com/world/2020/jan/01/story", AmpURL: "https://amp.theguardian.com/world/2020/jan/01/story"},
+			want: "https://www.theguardian.com/world/2020/jan/01/story",
+		},
+		{
+			name: "Google AMP viewer https",
+			item: Item{ResolvedURL: "https://www.google.com/amp/s/www.theguardian.com/world/2020/jan/01/story"},
+			want: "https://www.theguardian.com/world/2020/jan/01/story",
+		},
+		{
+			name: "Google AMP viewer http",
+			item: Item{ResolvedURL: "https://www.google.com/amp/www.example.com/news/1.amp"},
+			want: "http://www.example.com/news/1.amp",
+		},
+		{
+			name: "Google AMP viewer with query",
+			item: Item{GivenURL: "https://google.com/amp/s/example.com/a?id=7"},
+			want: "https://example.com/a?id=7",
+		},
+		{
+			name: "AMP cache",
+			item: Item{ResolvedURL: "https://www-bbc-com.cdn.ampproject.org/c/s/www.bbc.com/news/amp/world-1"},
+			want: "https://www.bbc.com/news/amp/world-1",
+		},
+		{
+			name: "Resolved is amp_url",
+			item: Item{GivenURL: "https://www.nytimes.com/2020/01/01/story.html", ResolvedURL: "https://www.nytimes.com/2020/01/01/story.amp.html", AmpURL: "https://www.nytimes.com/2020/01/01/story.amp.html"},
+			want: "https://www.nytimes.com/2020/01/01/story.html",
+		},
+		{
+			name: "Only AMP known",
+			item: Item{ResolvedURL: "https://amp.example.com/a", AmpURL: "https://amp.example.com/a"},
+			want: "https://amp.example.com/a",
+		},
+		{
+			name: "Google non-AMP page",
+			item: Item{ResolvedURL: "https://www.google.com/search?q=amp"},
+			want: "https://www.google.com/search?q=amp",
+		},
+		{
+			name: "Empty",
+			item: Item{},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.item.CanonicalURL())
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package pocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var ErrItemNotFound = errors.New("item not found")
+
+// GetItemByURL finds an already saved item by URL. Pocket's search narrows
+// the candidates down and the final match is done on normalized given_url
+// and resolved_url, so scheme, "www.", trailing slash, utm_* parameter and
+// fragment differences are ignored. ErrItemNotFound is returned when nothing matches.
+func (c *Client) GetItemByURL(ctx context.Context, accessToken, rawurl string) (*Item, error) {
+	return c.findItemByURL(ctx, accessToken, rawurl, "")
+}
+
+// findItemByURL is GetItemByURL retrieving the candidates with detail.
+func (c *Client) findItemByURL(ctx context.Context, accessToken, rawurl string, detail Detail) (*Item, error) {
+	accessToken = c.token(accessToken)
+	if accessToken == "" {
+		return nil, errNoAccessToken()
+	}
+
+	want, err := normalizeURL(rawurl)
+	if err != nil {
+		return nil, err
+	}
+
+	input := GetInput{
+		AccessToken: accessToken,
+		State:       StateAll,
+		Search:      searchTerm(want),
+		Detail:      detail,
+	}
+
+	for item, err := range c.Items(ctx, input) {
+		if err != nil {
+			return nil, err
+		}
+
+		if matchesURL(item, want) {
+			return &item, nil
+		}
+	}
+
+	return nil, ErrItemNotFound
+}
+
+// ArchiveByURL archives the saved item for rawurl, found like GetItemByURL
+// finds it. ErrItemNotFound is returned when the URL was never saved.
+func (c *Client) ArchiveByURL(ctx context.Context, accessToken, rawurl string, opts ...ActionOption) error {
+	item, err := c.GetItemByURL(ctx, accessToken, rawurl)
+	if err != nil {
+		return err
+	}
+
+	return c.Archive(ctx, accessToken, item.ItemID, opts...)
+}
+
+// DeleteByURL permanently deletes the saved item for rawurl, see
+// ArchiveByURL.
+func (c *Client) DeleteByURL(ctx context.Context, accessToken, rawurl string, opts ...ActionOption) error {
+	item, err := c.GetItemByURL(ctx, accessToken, rawurl)
+	if err != nil {
+		return err
+	}
+
+	return c.Delete(ctx, accessToken, item.ItemID, opts...)
+}
+
+// FavoriteByURL favorites the saved item for rawurl, see ArchiveByURL.
+func (c *Client) FavoriteByURL(ctx context.Context, accessToken, rawurl string, opts ...ActionOption) error {
+	item, err := c.GetItemByURL(ctx, accessToken, rawurl)
+	if err != nil {
+		return err
+	}
+
+	return c.Favorite(ctx, accessToken, item.ItemID, opts...)
+}
+
+// getItemByID pages through the account looking for itemID, since the
+// retrieve endpoint cannot filter by ID. ErrItemNotFound is returned when it
+// is not there.
+func (c *Client) getItemByID(ctx context.Context, accessToken string, itemID ItemID) (*Item, error) {
+	input := GetInput{
+		AccessToken: accessToken,
+		State:       StateAll,
+		Detail:      DetailSimple,
+	}
+
+	for item, err := range c.Items(ctx, input) {
+		if err != nil {
+			return nil, err
+		}
+
+		if item.ItemID == itemID {
+			return &item, nil
+		}
+	}
+
+	return nil, ErrItemNotFound
+}
+
+func matchesURL(item Item, want string) bool {
+	for _, candidate := range []string{item.GivenURL, item.ResolvedURL} {
+		if candidate == "" {
+			continue
+		}
+
+		got, err := normalizeURL(candidate)
+		if err == nil && got == want {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeURL reduces rawurl to a comparison key: scheme, "www.", default
+// ports, trailing slashes, utm_* tracking parameters and the fragment are
+// dropped, the host is lowercased and the remaining query parameters are
+// sorted.
+func normalizeURL(rawurl string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(rawurl))
+	if err != nil {
+		return "", errors.Join(err, errors.New("Failed to parse URL"))
+	}
+
+	if u.Host == "" {
+		return "", fmt.Errorf("URL %q is not absolute", rawurl)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	key := host + strings.TrimRight(u.EscapedPath(), "/")
+
+	query := u.Query()
+	for name := range query {
+		if strings.HasPrefix(strings.ToLower(name), "utm_") {
+			query.Del(name)
+		}
+	}
+	if len(query) > 0 {
+		key += "?" + query.Encode()
+	}
+
+	return key, nil
+}
+
+// searchTerm is the part of a normalized key Pocket's substring search will
+// find in both the http and https, www and bare variants of a URL.
+func searchTerm(key string) string {
+	if i := strings.IndexByte(key, '?'); i >= 0 {
+		key = key[:i]
+	}
+
+	return key
+}
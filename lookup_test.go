@@ -0,0 +1,167 @@
+package pocket
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawurl  string
+		want    string
+		wantErr bool
+	}{
+		{name: "Plain", rawurl: "https://lwn.net/Articles/1/", want: "lwn.net/Articles/1"},
+		{name: "HTTP", rawurl: "http://lwn.net/Articles/1", want: "lwn.net/Articles/1"},
+		{name: "Fragment", rawurl: "https://lwn.net/Articles/1/#comments", want: "lwn.net/Articles/1"},
+		{name: "WWW and case", rawurl: "HTTPS://WWW.LWN.NET/Articles/1", want: "lwn.net/Articles/1"},
+		{name: "Default port", rawurl: "https://lwn.net:443/Articles/1", want: "lwn.net/Articles/1"},
+		{name: "Custom port", rawurl: "http://localhost:8080/a", want: "localhost:8080/a"},
+		{name: "Query sorted", rawurl: "https://example.com/p?b=2&a=1", want: "example.com/p?a=1&b=2"},
+		{name: "Root", rawurl: "https://example.com/", want: "example.com"},
+		{name: "UTM dropped", rawurl: "https://example.com/p?utm_source=tw&id=7&UTM_Medium=social", want: "example.com/p?id=7"},
+		{name: "Only UTM", rawurl: "https://example.com/p/?utm_campaign=x", want: "example.com/p"},
+		{name: "Relative", rawurl: "/Articles/1", wantErr: true},
+		{name: "Garbage", rawurl: "http://[::1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeURL(tt.rawurl)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestClient_GetItemByURL(t *testing.T) {
+	response := `{"status":1,"list":{` +
+		`"1":{"item_id":"1","given_url":"https://lwn.net/Articles/1/extra","sort_id":0},` +
+		`"2":{"item_id":"2","given_url":"http://lwn.net/Articles/1","resolved_url":"https://lwn.net/Articles/1/","sort_id":1}` +
+		`}}`
+
+	tests := []struct {
+		name    string
+		rawurl  string
+		want    string
+		wantErr error
+	}{
+		{name: "Exact", rawurl: "https://lwn.net/Articles/1/", want: "2"},
+		{name: "HTTP vs HTTPS", rawurl: "http://lwn.net/Articles/1/", want: "2"},
+		{name: "Fragment", rawurl: "https://lwn.net/Articles/1#top", want: "2"},
+		{name: "Trailing slash", rawurl: "https://www.lwn.net/Articles/1", want: "2"},
+		{name: "Not saved", rawurl: "https://lwn.net/Articles/2/", wantErr: ErrItemNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, bodies := newScriptedClient(t, "/v3/get", response)
+
+			got, err := client.GetItemByURL(context.Background(), "access-to-ken", tt.rawurl)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, ItemID(tt.want), got.ItemID)
+			}
+
+			if assert.Len(t, *bodies, 1) {
+				assert.Contains(t, (*bodies)[0], `"state":"all"`)
+				assert.Contains(t, (*bodies)[0], `"search":"lwn.net/Articles/`)
+			}
+		})
+	}
+}
+
+func TestClient_GetItemByURL_InvalidInput(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/get")
+
+	_, err := client.GetItemByURL(context.Background(), "", "https://lwn.net")
+	assert.Error(t, err)
+
+	_, err = client.GetItemByURL(context.Background(), "access-to-ken", "not a url")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrItemNotFound)
+
+	assert.Empty(t, *bodies)
+}
+
+func TestClient_ByURL(t *testing.T) {
+	list := rawPage(
+		`{"item_id":"11","given_url":"https://example.com/post?utm_source=newsletter","sort_id":0}`,
+		`{"item_id":"12","given_url":"http://blog.example.org/a/","sort_id":1}`,
+	)
+
+	tests := []struct {
+		name     string
+		call     func(c *Client, rawurl string) error
+		rawurl   string
+		wantSend string
+		wantErr  error
+	}{
+		{
+			name:     "Archive",
+			call:     func(c *Client, u string) error { return c.ArchiveByURL(context.Background(), "access-to-ken", u) },
+			rawurl:   "https://www.example.com/post/",
+			wantSend: `{"action":"archive","item_id":"11"}`,
+		},
+		{
+			name:     "Delete",
+			call:     func(c *Client, u string) error { return c.DeleteByURL(context.Background(), "access-to-ken", u) },
+			rawurl:   "https://blog.example.org/a?utm_medium=email",
+			wantSend: `{"action":"delete","item_id":"12"}`,
+		},
+		{
+			name:     "Favorite",
+			call:     func(c *Client, u string) error { return c.FavoriteByURL(context.Background(), "access-to-ken", u) },
+			rawurl:   "http://example.com/post#comments",
+			wantSend: `{"action":"favorite","item_id":"11"}`,
+		},
+		{
+			name:    "Never saved",
+			call:    func(c *Client, u string) error { return c.ArchiveByURL(context.Background(), "access-to-ken", u) },
+			rawurl:  "https://example.com/other",
+			wantErr: ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []string
+			client := &Client{
+				client: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+					resp := list
+					if r.URL.Path == "/v3/send" {
+						sent = append(sent, readBody(t, r))
+						resp = `{"status":1,"action_results":[true]}`
+					}
+
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(resp))}, nil
+				})},
+				consumerKey: "key",
+			}
+
+			err := tt.call(client, tt.rawurl)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, sent)
+				return
+			}
+
+			assert.NoError(t, err)
+			if assert.Len(t, sent, 1) {
+				assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","actions":[`+tt.wantSend+`]}`, sent[0])
+			}
+		})
+	}
+}
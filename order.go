@@ -0,0 +1,68 @@
+package pocket
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortField is a key SortItems can order by.
+type SortField int
+
+const (
+	SortByTimeAdded SortField = iota
+	SortByTimeUpdated
+	SortByWordCount
+	SortByTitle
+	SortByDomain
+)
+
+func (f SortField) valid() bool {
+	return f >= SortByTimeAdded && f <= SortByDomain
+}
+
+// SortItems sorts items in place by the given field, ascending unless desc is
+// set. The sort is stable, so items with equal keys keep their relative
+// order in either direction. Titles compare case-insensitively, preferring
+// the resolved title over the given one. An unknown field is a
+// *ValidationError and leaves items untouched.
+func SortItems(items []Item, by SortField, desc bool) error {
+	if !by.valid() {
+		return &ValidationError{Field: "by", Reason: fmt.Sprintf("unknown sort field %d", by)}
+	}
+
+	cmp := itemComparator(by)
+
+	sort.SliceStable(items, func(a, b int) bool {
+		if desc {
+			return cmp(items[b], items[a]) < 0
+		}
+		return cmp(items[a], items[b]) < 0
+	})
+
+	return nil
+}
+
+func itemComparator(by SortField) func(a, b Item) int {
+	switch by {
+	case SortByTimeUpdated:
+		return func(a, b Item) int { return a.TimeUpdated.Compare(b.TimeUpdated) }
+	case SortByWordCount:
+		return func(a, b Item) int { return a.WordCount - b.WordCount }
+	case SortByTitle:
+		return func(a, b Item) int { return strings.Compare(foldedTitle(a), foldedTitle(b)) }
+	case SortByDomain:
+		return func(a, b Item) int { return strings.Compare(a.Domain(), b.Domain()) }
+	default:
+		return func(a, b Item) int { return a.TimeAdded.Compare(b.TimeAdded) }
+	}
+}
+
+func foldedTitle(i Item) string {
+	title := i.ResolvedTitle
+	if title == "" {
+		title = i.GivenTitle
+	}
+
+	return strings.ToLower(title)
+}
@@ -0,0 +1,111 @@
+package pocket
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortItems(t *testing.T) {
+	at := func(sec int64) time.Time { return time.Unix(sec, 0).UTC() }
+
+	items := []Item{
+		{ItemID: "1", ResolvedTitle: "beta", TimeAdded: at(30), TimeUpdated: at(1), WordCount: 500, ResolvedURL: "https://b.com/x"},
+		{ItemID: "2", GivenTitle: "Alpha", TimeAdded: at(10), TimeUpdated: at(3), WordCount: 100, GivenURL: "https://www.A.com/x"},
+		{ItemID: "3", ResolvedTitle: "Gamma", TimeAdded: at(20), TimeUpdated: at(2), WordCount: 500, ResolvedURL: "https://c.com/"},
+	}
+
+	tests := []struct {
+		name string
+		by   SortField
+		desc bool
+		want []string
+	}{
+		{name: "Added ascending", by: SortByTimeAdded, want: []string{"2", "3", "1"}},
+		{name: "Added descending", by: SortByTimeAdded, desc: true, want: []string{"1", "3", "2"}},
+		{name: "Updated", by: SortByTimeUpdated, want: []string{"1", "3", "2"}},
+		{name: "Word count stable", by: SortByWordCount, want: []string{"2", "1", "3"}},
+		{name: "Word count descending stable", by: SortByWordCount, desc: true, want: []string{"1", "3", "2"}},
+		{name: "Title casefolded", by: SortByTitle, want: []string{"2", "1", "3"}},
+		{name: "Domain", by: SortByDomain, want: []string{"2", "1", "3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := append([]Item(nil), items...)
+			assert.NoError(t, SortItems(got, tt.by, tt.desc))
+			assert.Equal(t, tt.want, itemIDs(got))
+		})
+	}
+}
+
+func TestSortItems_UnknownField(t *testing.T) {
+	items := []Item{{ItemID: "2"}, {ItemID: "1"}}
+
+	err := SortItems(items, SortByDomain+1, false)
+
+	var verr *ValidationError
+	if assert.ErrorAs(t, err, &verr) {
+		assert.Equal(t, "by", verr.Field)
+	}
+	assert.Equal(t, []string{"2", "1"}, itemIDs(items))
+}
+
+// TestSortItems_Properties checks on random inputs that the output is a
+// permutation of the input, that adjacent items are ordered, and that equal
+// keys keep their input order.
+func TestSortItems_Properties(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	titles := []string{"alpha", "Alpha", "BETA", "beta", "Гамма", "гамма", ""}
+	hosts := []string{"a.com", "www.b.com", "C.com", ""}
+
+	fields := []SortField{SortByTimeAdded, SortByTimeUpdated, SortByWordCount, SortByTitle, SortByDomain}
+
+	for round := 0; round < 50; round++ {
+		items := make([]Item, rng.Intn(40))
+		for i := range items {
+			items[i] = Item{
+				ItemID:        ItemID(fmt.Sprint(i)),
+				ResolvedTitle: titles[rng.Intn(len(titles))],
+				GivenTitle:    titles[rng.Intn(len(titles))],
+				TimeAdded:     time.Unix(int64(rng.Intn(5)), 0),
+				TimeUpdated:   time.Unix(int64(rng.Intn(5)), 0),
+				WordCount:     rng.Intn(5) * 100,
+				ResolvedURL:   "https://" + hosts[rng.Intn(len(hosts))] + "/p",
+			}
+		}
+
+		for _, by := range fields {
+			for _, desc := range []bool{false, true} {
+				got := append([]Item(nil), items...)
+				assert.NoError(t, SortItems(got, by, desc))
+
+				wantIDs := itemIDs(items)
+				gotIDs := itemIDs(got)
+				sort.Strings(wantIDs)
+				sort.Strings(gotIDs)
+				assert.Equal(t, wantIDs, gotIDs, "not a permutation")
+
+				cmp := itemComparator(by)
+				for i := 1; i < len(got); i++ {
+					c := cmp(got[i-1], got[i])
+					if desc {
+						c = -c
+					}
+					assert.LessOrEqual(t, c, 0, "out of order at %d", i)
+
+					if c == 0 {
+						var prev, cur int
+						fmt.Sscan(string(got[i-1].ItemID), &prev)
+						fmt.Sscan(string(got[i].ItemID), &cur)
+						assert.Less(t, prev, cur, "unstable at %d", i)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package pocket
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// Pages fetches consecutive pages for input, starting at input.Offset, and
+// yields each one with items already seen on earlier pages removed. An error
+// is yielded once and ends the sequence. The Since of the last page is the
+// checkpoint for the next delta retrieve.
+func (c *Client) Pages(ctx context.Context, input GetInput) iter.Seq2[*GetResponse, error] {
+	return func(yield func(*GetResponse, error) bool) {
+		if input.Count == 0 {
+			input.Count = maxCount
+		}
+
+		input.AccessToken = c.token(input.AccessToken)
+		if err := input.validate(); err != nil {
+			yield(nil, err)
+			return
+		}
+
+		seen := make(map[ItemID]struct{})
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			resp, err := c.Get(ctx, input)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			fetched := len(resp.Items)
+
+			fresh := resp.Items[:0]
+			for _, item := range resp.Items {
+				if _, ok := seen[item.ItemID]; ok {
+					continue
+				}
+				seen[item.ItemID] = struct{}{}
+				fresh = append(fresh, item)
+			}
+			resp.Items = fresh
+
+			if !yield(resp, nil) {
+				return
+			}
+
+			if fetched < input.Count {
+				return
+			}
+
+			// A full page of items we already have means the server is
+			// ignoring the offset; bail out instead of looping forever.
+			if len(fresh) == 0 {
+				yield(nil, errors.New("Server returned the same page twice"))
+				return
+			}
+
+			input.Offset += fetched
+		}
+	}
+}
+
+// GetAllOptions tunes GetAllWithOptions.
+type GetAllOptions struct {
+	// Prefetch is the number of pages requested ahead of the one being
+	// consumed. Zero fetches pages strictly one after another.
+	Prefetch int
+}
+
+// GetAll pages through every item matching input, starting at input.Offset.
+// input.Count sets the page size and defaults to the maximum Pocket allows.
+func (c *Client) GetAll(ctx context.Context, input GetInput) ([]Item, error) {
+	resp, err := c.GetAllWithOptions(ctx, input, GetAllOptions{})
+	if resp == nil {
+		return nil, err
+	}
+
+	return resp.Items, err
+}
+
+// GetAllWithOptions is GetAll with tuning knobs. With Prefetch set, the next
+// pages are requested concurrently while results keep their list order.
+//
+// The returned response holds every item fetched, plus Since and Total from
+// the final page. On error it still carries the items fetched so far.
+func (c *Client) GetAllWithOptions(ctx context.Context, input GetInput, opts GetAllOptions) (*GetResponse, error) {
+	if opts.Prefetch < 0 {
+		return nil, errors.New("prefetch is negative")
+	}
+
+	if opts.Prefetch > 0 {
+		return c.getAllPrefetch(ctx, input, opts.Prefetch)
+	}
+
+	all := &GetResponse{Total: -1}
+	for resp, err := range c.Pages(ctx, input) {
+		if err != nil {
+			return all, err
+		}
+		all.add(resp)
+	}
+
+	return all, nil
+}
+
+// add appends the items of a later page and takes over its checkpoint.
+func (r *GetResponse) add(page *GetResponse) {
+	r.Items = append(r.Items, page.Items...)
+	r.Since = page.Since
+	r.Total = page.Total
+}
+
+type pageResult struct {
+	resp *GetResponse
+	err  error
+}
+
+// getAllPrefetch keeps prefetch+1 page requests in flight at consecutive
+// offsets and consumes them in order. Requests still in flight when the list
+// ends or a page fails are cancelled.
+func (c *Client) getAllPrefetch(ctx context.Context, input GetInput, prefetch int) (*GetResponse, error) {
+	if input.Count == 0 {
+		input.Count = maxCount
+	}
+
+	input.AccessToken = c.token(input.AccessToken)
+	if err := input.validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	next := input.Offset
+	launch := func() <-chan pageResult {
+		ch := make(chan pageResult, 1)
+
+		in := input
+		in.Offset = next
+		next += input.Count
+
+		go func() {
+			resp, err := c.Get(ctx, in)
+			ch <- pageResult{resp: resp, err: err}
+		}()
+
+		return ch
+	}
+
+	queue := make([]<-chan pageResult, 0, prefetch+1)
+	for len(queue) < prefetch+1 {
+		queue = append(queue, launch())
+	}
+
+	all := &GetResponse{Total: -1}
+	seen := make(map[ItemID]struct{})
+
+	for {
+		var res pageResult
+		select {
+		case res = <-queue[0]:
+		case <-ctx.Done():
+			return all, ctx.Err()
+		}
+		queue = queue[1:]
+
+		if res.err != nil {
+			return all, res.err
+		}
+
+		fetched := len(res.resp.Items)
+
+		fresh := res.resp.Items[:0]
+		for _, item := range res.resp.Items {
+			if _, ok := seen[item.ItemID]; ok {
+				continue
+			}
+			seen[item.ItemID] = struct{}{}
+			fresh = append(fresh, item)
+		}
+		res.resp.Items = fresh
+		all.add(res.resp)
+
+		if fetched < input.Count {
+			return all, nil
+		}
+
+		if len(fresh) == 0 {
+			return all, errors.New("Server returned the same page twice")
+		}
+
+		queue = append(queue, launch())
+	}
+}
+
+// Items lazily iterates over every item matching input, fetching the next
+// page only once the previous one has been consumed. Breaking out of the loop
+// stops further requests; a failed page is yielded as a single error.
+func (c *Client) Items(ctx context.Context, input GetInput) iter.Seq2[Item, error] {
+	return func(yield func(Item, error) bool) {
+		for resp, err := range c.Pages(ctx, input) {
+			if err != nil {
+				yield(Item{}, err)
+				return
+			}
+
+			for _, item := range resp.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// GetStream fetches pages in a background goroutine and sends their items on
+// the returned channel. Both channels are closed once the list is exhausted,
+// a page fails or ctx is cancelled; at most one error is sent. The item
+// channel is unbuffered, so a slow consumer holds back further requests.
+func (c *Client) GetStream(ctx context.Context, input GetInput) (<-chan Item, <-chan error) {
+	items := make(chan Item)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(items)
+
+		for item, err := range c.Items(ctx, input) {
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			select {
+			case items <- item:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return items, errs
+}
@@ -0,0 +1,448 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// page builds a retrieve response holding items with the given IDs, in order.
+func page(ids ...string) string {
+	var b strings.Builder
+
+	b.WriteString(`{"status":1,"list":{`)
+	for i, id := range ids {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `"%s":{"item_id":"%s","sort_id":%d}`, id, id, i)
+	}
+	b.WriteString(`},"since":1471870000}`)
+
+	return b.String()
+}
+
+func itemIDs(items []Item) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, string(item.ItemID))
+	}
+
+	return ids
+}
+
+func TestClient_GetAll(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/get",
+		page("1", "2"),
+		page("3", "4"),
+		page("5"),
+	)
+
+	got, err := client.GetAll(context.Background(), GetInput{AccessToken: "access-to-ken", Count: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, itemIDs(got))
+
+	if assert.Len(t, *bodies, 3) {
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","count":2}`, (*bodies)[0])
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","count":2,"offset":2}`, (*bodies)[1])
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","count":2,"offset":4}`, (*bodies)[2])
+	}
+}
+
+func TestClient_GetAll_EmptyLastPage(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/get",
+		page("1", "2"),
+		page(),
+	)
+
+	got, err := client.GetAll(context.Background(), GetInput{AccessToken: "access-to-ken", Count: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, itemIDs(got))
+	assert.Len(t, *bodies, 2)
+}
+
+func TestClient_GetAll_DefaultPageSize(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/get", page("1"))
+
+	_, err := client.GetAll(context.Background(), GetInput{AccessToken: "access-to-ken"})
+	assert.NoError(t, err)
+	if assert.Len(t, *bodies, 1) {
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","count":30}`, (*bodies)[0])
+	}
+}
+
+func TestClient_GetAll_RepeatedPage(t *testing.T) {
+	client, _ := newScriptedClient(t, "/v3/get",
+		page("1", "2"),
+		page("1", "2"),
+	)
+
+	got, err := client.GetAll(context.Background(), GetInput{AccessToken: "access-to-ken", Count: 2})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"1", "2"}, itemIDs(got))
+}
+
+func TestClient_GetAll_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	client, bodies := newScriptedClient(t, "/v3/get", page("1", "2"), page("3", "4"))
+	transport := client.client.Transport
+	client.client.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		defer cancel()
+		return transport.RoundTrip(r)
+	})
+
+	got, err := client.GetAll(ctx, GetInput{AccessToken: "access-to-ken", Count: 2})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{"1", "2"}, itemIDs(got))
+	assert.Len(t, *bodies, 1)
+}
+
+func TestClient_Items(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/get",
+		page("1", "2"),
+		page("3", "4"),
+		page("5"),
+	)
+
+	var ids []string
+	for item, err := range client.Items(context.Background(), GetInput{AccessToken: "access-to-ken", Count: 2}) {
+		assert.NoError(t, err)
+		ids = append(ids, string(item.ItemID))
+	}
+
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, ids)
+	assert.Len(t, *bodies, 3)
+}
+
+func TestClient_Items_Break(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/get",
+		page("1", "2"),
+		page("3", "4"),
+	)
+
+	var ids []string
+	for item, err := range client.Items(context.Background(), GetInput{AccessToken: "access-to-ken", Count: 2}) {
+		assert.NoError(t, err)
+		ids = append(ids, string(item.ItemID))
+		if item.ItemID == "2" {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{"1", "2"}, ids)
+	assert.Len(t, *bodies, 1)
+}
+
+func TestClient_Items_Error(t *testing.T) {
+	client, _ := newScriptedClient(t, "/v3/get",
+		page("1", "2"),
+		`not json`,
+	)
+
+	var (
+		ids  []string
+		errs []error
+	)
+	for item, err := range client.Items(context.Background(), GetInput{AccessToken: "access-to-ken", Count: 2}) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, string(item.ItemID))
+	}
+
+	assert.Equal(t, []string{"1", "2"}, ids)
+	assert.Len(t, errs, 1)
+}
+
+func TestClient_Items_InvalidInput(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/get")
+
+	var errs []error
+	for _, err := range client.Items(context.Background(), GetInput{}) {
+		errs = append(errs, err)
+	}
+
+	if assert.Len(t, errs, 1) {
+		assert.Error(t, errs[0])
+	}
+	assert.Empty(t, *bodies)
+}
+
+func TestClient_GetStream(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/get",
+		page("1", "2"),
+		page("3", "4"),
+		page("5"),
+	)
+
+	items, errs := client.GetStream(context.Background(), GetInput{AccessToken: "access-to-ken", Count: 2})
+
+	var ids []string
+	for item := range items {
+		ids = append(ids, string(item.ItemID))
+	}
+
+	assert.NoError(t, <-errs)
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, ids)
+	assert.Len(t, *bodies, 3)
+}
+
+func TestClient_GetStream_Error(t *testing.T) {
+	client, _ := newScriptedClient(t, "/v3/get", page("1", "2"), `not json`)
+
+	items, errs := client.GetStream(context.Background(), GetInput{AccessToken: "access-to-ken", Count: 2})
+
+	var ids []string
+	for item := range items {
+		ids = append(ids, string(item.ItemID))
+	}
+
+	assert.Error(t, <-errs)
+	assert.Equal(t, []string{"1", "2"}, ids)
+}
+
+func TestClient_GetStream_Cancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client, bodies := newScriptedClient(t, "/v3/get",
+		page("1", "2"),
+		page("3", "4"),
+		page("5"),
+	)
+
+	items, errs := client.GetStream(ctx, GetInput{AccessToken: "access-to-ken", Count: 2})
+
+	item := <-items
+	assert.Equal(t, ItemID("1"), item.ItemID)
+	cancel()
+
+	// Drain until the producer notices the cancellation and closes up.
+	for range items {
+	}
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	assert.LessOrEqual(t, len(*bodies), 2)
+
+	// No goleak in this module, so compare goroutine counts instead.
+	// assert.Eventually runs its own goroutines, so poll by hand.
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before)
+}
+
+// newPagedClient serves an account of total items, honouring the count and
+// offset of each request, after sleeping for latency to mimic a round trip.
+// A request for failOffset gets a 503; pass -1 to never fail.
+func newPagedClient(tb testing.TB, total int, latency time.Duration, failOffset int) (*Client, *atomic.Int32) {
+	var requests atomic.Int32
+
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests.Add(1)
+
+		var req getRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			tb.Error(err)
+		}
+
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		}
+
+		if req.Offset == failOffset {
+			return &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     http.Header{xErrorHeader: []string{"Pocket server is down"}},
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		}
+
+		var ids []string
+		for i := req.Offset; i < req.Offset+req.Count && i < total; i++ {
+			ids = append(ids, strconv.Itoa(i+1))
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(page(ids...))),
+		}, nil
+	})
+
+	return &Client{client: &http.Client{Transport: transport}, consumerKey: "key"}, &requests
+}
+
+func TestClient_GetAllWithOptions_Prefetch(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int
+		prefetch int
+	}{
+		{name: "Sequential", total: 95, prefetch: 0},
+		{name: "Prefetch 1", total: 95, prefetch: 1},
+		{name: "Prefetch 3", total: 95, prefetch: 3},
+		{name: "Exact pages", total: 90, prefetch: 3},
+		{name: "Shorter than prefetch window", total: 10, prefetch: 5},
+		{name: "Empty account", total: 0, prefetch: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newPagedClient(t, tt.total, time.Millisecond, -1)
+
+			got, err := client.GetAllWithOptions(context.Background(), GetInput{AccessToken: "access-to-ken"}, GetAllOptions{Prefetch: tt.prefetch})
+			assert.NoError(t, err)
+
+			want := []string{}
+			for i := 1; i <= tt.total; i++ {
+				want = append(want, strconv.Itoa(i))
+			}
+			assert.Equal(t, want, itemIDs(got.Items))
+			assert.Equal(t, time.Unix(1471870000, 0).UTC(), got.Since)
+		})
+	}
+}
+
+func TestClient_GetAllWithOptions_PrefetchError(t *testing.T) {
+	client, _ := newPagedClient(t, 10, time.Millisecond, 2)
+
+	got, err := client.GetAllWithOptions(context.Background(), GetInput{AccessToken: "access-to-ken", Count: 2}, GetAllOptions{Prefetch: 2})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"1", "2"}, itemIDs(got.Items))
+}
+
+func TestClient_GetAllWithOptions_PrefetchCancelled(t *testing.T) {
+	client, _ := newPagedClient(t, 300, 50*time.Millisecond, -1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetAllWithOptions(ctx, GetInput{AccessToken: "access-to-ken"}, GetAllOptions{Prefetch: 3})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_GetAllWithOptions_NegativePrefetch(t *testing.T) {
+	client, requests := newPagedClient(t, 10, 0, -1)
+
+	_, err := client.GetAllWithOptions(context.Background(), GetInput{AccessToken: "access-to-ken"}, GetAllOptions{Prefetch: -1})
+	assert.Error(t, err)
+	assert.Zero(t, requests.Load())
+}
+
+func BenchmarkClient_GetAllWithOptions(b *testing.B) {
+	for _, prefetch := range []int{0, 1, 3, 7} {
+		b.Run(fmt.Sprintf("prefetch=%d", prefetch), func(b *testing.B) {
+			client, _ := newPagedClient(b, 600, time.Millisecond, -1)
+
+			for b.Loop() {
+				_, err := client.GetAllWithOptions(context.Background(), GetInput{AccessToken: "access-to-ken"}, GetAllOptions{Prefetch: prefetch})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_GetAllWithOptions_Since(t *testing.T) {
+	client, _ := newScriptedClient(t, "/v3/get",
+		`{"status":1,"list":{"1":{"item_id":"1"},"2":{"item_id":"2","sort_id":1}},"since":1471870000}`,
+		`{"status":1,"list":{"3":{"item_id":"3"}},"since":1471870005}`,
+	)
+
+	got, err := client.GetAllWithOptions(context.Background(), GetInput{AccessToken: "access-to-ken", Count: 2}, GetAllOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, itemIDs(got.Items))
+	assert.Equal(t, time.Unix(1471870005, 0).UTC(), got.Since)
+}
+
+func TestClient_Pages_Since(t *testing.T) {
+	client, _ := newScriptedClient(t, "/v3/get",
+		`{"status":1,"list":{"1":{"item_id":"1"},"2":{"item_id":"2","sort_id":1}},"since":1471870000}`,
+		`{"status":1,"list":{},"since":"1471870005"}`,
+	)
+
+	var since time.Time
+	for resp, err := range client.Pages(context.Background(), GetInput{AccessToken: "access-to-ken", Count: 2}) {
+		assert.NoError(t, err)
+		since = resp.Since
+	}
+
+	assert.Equal(t, time.Unix(1471870005, 0).UTC(), since)
+}
+
+// TestClient_GetAll_SortIDOrder feeds pages whose keys are deliberately out
+// of order and checks that every slice-producing path follows sort_id.
+func TestClient_GetAll_SortIDOrder(t *testing.T) {
+	responses := []string{
+		`{"status":1,"list":{` +
+			`"900":{"item_id":"900","sort_id":2},` +
+			`"100":{"item_id":"100","sort_id":0},` +
+			`"500":{"item_id":"500","sort_id":1}` +
+			`}}`,
+		`{"status":1,"list":{` +
+			`"300":{"item_id":"300","sort_id":4},` +
+			`"800":{"item_id":"800","sort_id":3}` +
+			`}}`,
+	}
+	want := []string{"100", "500", "900", "800", "300"}
+	input := GetInput{AccessToken: "access-to-ken", Count: 3}
+
+	t.Run("GetAll", func(t *testing.T) {
+		client, _ := newScriptedClient(t, "/v3/get", responses...)
+
+		got, err := client.GetAll(context.Background(), input)
+		assert.NoError(t, err)
+		assert.Equal(t, want, itemIDs(got))
+	})
+
+	t.Run("Items", func(t *testing.T) {
+		client, _ := newScriptedClient(t, "/v3/get", responses...)
+
+		var ids []string
+		for item, err := range client.Items(context.Background(), input) {
+			assert.NoError(t, err)
+			ids = append(ids, string(item.ItemID))
+		}
+		assert.Equal(t, want, ids)
+	})
+
+	t.Run("GetStream", func(t *testing.T) {
+		client, _ := newScriptedClient(t, "/v3/get", responses...)
+
+		items, errs := client.GetStream(context.Background(), input)
+
+		var ids []string
+		for item := range items {
+			ids = append(ids, string(item.ItemID))
+		}
+		assert.NoError(t, <-errs)
+		assert.Equal(t, want, ids)
+	})
+}
+
+func TestSortBySortID_TiesKeepPayloadOrder(t *testing.T) {
+	items := []Item{
+		{ItemID: "c", SortID: 1},
+		{ItemID: "a"},
+		{ItemID: "b"},
+		{ItemID: "d", SortID: 1},
+	}
+
+	sortBySortID(items)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, itemIDs(items))
+}
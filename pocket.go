@@ -16,13 +16,16 @@ import (
 const (
 	host = "https://getpocket.com/v3"
 
-	authorizeURL = "https://getpocket.com/auth/authorize?request_token=%s&redirect_uri=%s"
+	authorizeURL = "https://getpocket.com/auth/authorize"
 
 	endpointRequestToken = "/oauth/request"
 	endpointAuthorize    = "/oauth/authorize"
 	endpointAdd          = "/add"
+	endpointGet          = "/get"
 
-	xErrorHeader = "X-Error"
+	xErrorHeader     = "X-Error"
+	xErrorCodeHeader = "X-Error-Code"
+	xAcceptHeader    = "X-Accept"
 
 	defaultTimeout = 5 * time.Second
 )
@@ -31,6 +34,14 @@ type (
 	requestTokenRequest struct {
 		ConsumerKey string `json:"consumer_key"`
 		RedirectURI string `json:"redirect_uri"`
+		State       string `json:"state,omitempty"`
+	}
+
+	// RequestTokenResponse is the request token together with the state it
+	// was requested with, as echoed by Pocket.
+	RequestTokenResponse struct {
+		Code  string `json:"code"`
+		State string `json:"state"`
 	}
 
 	accessTokenRequest struct {
@@ -43,33 +54,149 @@ type (
 		Username    string `json:"username"`
 	}
 
+	// addRequest leaves out empty fields: an explicit empty title would
+	// override the one Pocket resolves from the page.
 	addRequest struct {
-		Url         string `json:"url"`
-		Title       string `json:"title"`
-		Tags        string `json:"tags"`
-		TweetId     int    `json:"tweet_id"`
-		ConsumerKey string `json:"consumer_key"`
-		AccessToken string `json:"access_token"`
+		Url         string `json:"url,omitempty"`
+		Title       string `json:"title,omitempty"`
+		Tags        string `json:"tags,omitempty"`
+		TweetID     string `json:"tweet_id,omitempty"`
+		RefID       string `json:"ref_id,omitempty"`
+		ConsumerKey string `json:"consumer_key,omitempty"`
+		AccessToken string `json:"access_token,omitempty"`
+	}
+
+	addResponse struct {
+		Item   *AddedItem `json:"item"`
+		Status int        `json:"status"`
 	}
 
 	AddInput struct {
-		URL         string
-		Title       string
-		Tags        []string
+		URL string
+		// ParsedURL may be set instead of URL by callers holding a parsed
+		// URL already. It is checked like URL but not parsed again.
+		ParsedURL *url.URL
+		Title     string
+		Tags      []string
+		// AccessToken defaults to the client's; see Client.WithAccessToken.
 		AccessToken string
+		// TweetID attributes the save to a tweet. Only Add sends it.
+		TweetID string
+		// RefID attributes the save to the item it was recommended or
+		// syndicated from.
+		RefID string
+		// Time is when the URL was saved. Only AddBatchActions sends it; the
+		// add endpoint always uses the current time.
+		Time time.Time
+
+		skipIfExists  bool
+		truncateTitle bool
+		readd         bool
 	}
 )
 
-func (i AddInput) validate() error {
-	if i.URL == "" {
-		return errors.New("required URL values is empty")
+// validate checks i and returns it with the URL cleaned up by cleanURL and
+// the tags by sanitizeTags, as the send path does.
+func (i AddInput) validate() (AddInput, error) {
+	u, err := i.cleanURL()
+	if err != nil {
+		return i, err
+	}
+	i.URL, i.ParsedURL = u, nil
+
+	if i.Title, err = cleanTitle(i.Title, i.truncateTitle); err != nil {
+		return i, err
+	}
+
+	if i.Tags, err = sanitizeTags(i.Tags); err != nil {
+		return i, err
 	}
 
 	if i.AccessToken == "" {
-		return errors.New("access token is empty")
+		return i, errNoAccessToken()
 	}
 
-	return nil
+	return i, nil
+}
+
+// cleanURL returns the URL to save, from ParsedURL when set and URL
+// otherwise, as cleaned up by cleanURL or formatURL.
+func (i AddInput) cleanURL() (string, error) {
+	if i.ParsedURL == nil {
+		return cleanURL(i.URL)
+	}
+
+	if i.URL != "" {
+		return "", &ValidationError{Field: "ParsedURL", Reason: "is set together with URL"}
+	}
+
+	return formatURL("ParsedURL", i.ParsedURL.String(), i.ParsedURL)
+}
+
+// cleanURL trims rawurl and checks it with formatURL.
+func cleanURL(rawurl string) (string, error) {
+	rawurl = strings.TrimSpace(rawurl)
+	if rawurl == "" {
+		return "", &ValidationError{Field: "URL", Reason: "is empty"}
+	}
+
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return "", &ValidationError{Field: "URL", Reason: fmt.Sprintf("%q does not parse", rawurl)}
+	}
+
+	return formatURL("URL", rawurl, u)
+}
+
+// formatURL checks that u is an absolute http or https URL, so typos are
+// caught before Pocket answers with an opaque error, and formats it.
+// Characters not allowed in a URL are percent-encoded in the path, query and
+// fragment; the host is kept as given, so IDN hosts keep their Unicode form.
+// field and rawurl are only used to report errors.
+func formatURL(field, rawurl string, u *url.URL) (string, error) {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", &ValidationError{Field: field, Reason: fmt.Sprintf("%q is not an http or https URL", rawurl)}
+	}
+
+	if u.Host == "" {
+		return "", &ValidationError{Field: field, Reason: fmt.Sprintf("%q has no host", rawurl)}
+	}
+
+	var b strings.Builder
+	b.WriteString(u.Scheme + "://")
+	if u.User != nil {
+		b.WriteString(u.User.String() + "@")
+	}
+	b.WriteString(u.Host)
+	b.WriteString(u.EscapedPath())
+	if u.ForceQuery || u.RawQuery != "" {
+		b.WriteString("?" + escapeUnsafe(u.RawQuery))
+	}
+	if u.Fragment != "" {
+		b.WriteString("#" + u.EscapedFragment())
+	}
+
+	return b.String(), nil
+}
+
+// escapeUnsafe percent-encodes the bytes of s that may not appear in a URL,
+// leaving existing escapes and reserved characters alone.
+func escapeUnsafe(s string) string {
+	const hex = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= ' ' || c >= 0x7f || strings.IndexByte(`"<>\^`+"`{|}", c) >= 0 {
+			b.WriteByte('%')
+			b.WriteByte(hex[c>>4])
+			b.WriteByte(hex[c&15])
+			continue
+		}
+		b.WriteByte(c)
+	}
+
+	return b.String()
 }
 
 func (i AddInput) generateRequest(consumerKey string) addRequest {
@@ -77,6 +204,8 @@ func (i AddInput) generateRequest(consumerKey string) addRequest {
 		Url:         i.URL,
 		Tags:        strings.Join(i.Tags, ","),
 		Title:       i.Title,
+		TweetID:     i.TweetID,
+		RefID:       i.RefID,
 		AccessToken: i.AccessToken,
 		ConsumerKey: consumerKey,
 	}
@@ -85,6 +214,8 @@ func (i AddInput) generateRequest(consumerKey string) addRequest {
 type Client struct {
 	client      *http.Client
 	consumerKey string
+	accessToken string
+	dryRun      bool
 }
 
 func NewClient(consumerKey string) (*Client, error) {
@@ -100,30 +231,88 @@ func NewClient(consumerKey string) (*Client, error) {
 	}, nil
 }
 
-func (c *Client) GetRequestToken(ctx context.Context, redirectUri string) (string, error) {
+// WithAccessToken returns a copy of c that acts for the user of
+// accessToken whenever a call is given no token of its own. The copy shares
+// c's HTTP client; c itself is unchanged and can keep serving other users.
+func (c *Client) WithAccessToken(accessToken string) *Client {
+	authorized := *c
+	authorized.accessToken = accessToken
+
+	return &authorized
+}
+
+// token returns accessToken, or the client's token when it is empty.
+func (c *Client) token(accessToken string) string {
+	if accessToken != "" {
+		return accessToken
+	}
+
+	return c.accessToken
+}
+
+func errNoAccessToken() error {
+	return &ValidationError{Field: "AccessToken", Reason: "is empty and the client has none; see Client.WithAccessToken"}
+}
+
+// DryRun returns a copy of c on which every modifying call behaves as if
+// given WithDryRun. c itself is unchanged.
+func (c *Client) DryRun() *Client {
+	dry := *c
+	dry.dryRun = true
+
+	return &dry
+}
+
+func (c *Client) dryRunning(opts []ActionOption) bool {
+	return c.dryRun || applyActionOptions(opts).dryRun
+}
+
+// GetRequestToken starts the OAuth flow and returns the request token. It is
+// GetRequestTokenResponse without the state.
+func (c *Client) GetRequestToken(ctx context.Context, redirectUri string, opts ...RequestTokenOption) (string, error) {
+	resp, err := c.GetRequestTokenResponse(ctx, redirectUri, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Code, nil
+}
+
+// GetRequestTokenResponse starts the OAuth flow and returns the request token
+// together with the state Pocket echoed back; see WithOAuthState.
+func (c *Client) GetRequestTokenResponse(ctx context.Context, redirectUri string, opts ...RequestTokenOption) (*RequestTokenResponse, error) {
 	if redirectUri == "" {
-		return "", errors.New("ReditectUri is empty")
+		return nil, errors.New("ReditectUri is empty")
 	}
 
 	inp := requestTokenRequest{
 		ConsumerKey: c.consumerKey,
 		RedirectURI: redirectUri,
 	}
+	for _, opt := range opts {
+		opt(&inp)
+	}
 
-	values, err := c.doHTTP(ctx, endpointRequestToken, inp)
+	var resp RequestTokenResponse
+	err := c.doJSONOrForm(ctx, endpointRequestToken, inp, &resp, func(values url.Values) {
+		resp.Code = values.Get("code")
+		resp.State = values.Get("state")
+	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	requestToken := values.Get("code")
-	if requestToken == "" {
-		return "", errors.New("Empty request token in API response")
+	if resp.Code == "" {
+		return nil, errors.New("Empty request token in API response")
 	}
 
-	return requestToken, nil
+	return &resp, nil
 }
 
-func (c *Client) GetAuthorizationURL(ctx context.Context, requestToken, redirectUrl string) (string, error) {
+// GetAuthorizationURL returns the page the user approves the request token
+// on. Both parameters are query-escaped, so redirectUrl may carry a query of
+// its own.
+func (c *Client) GetAuthorizationURL(ctx context.Context, requestToken, redirectUrl string, opts ...AuthURLOption) (string, error) {
 	if requestToken == "" {
 		return "", errors.New("RequestToken is empty")
 	}
@@ -131,24 +320,96 @@ func (c *Client) GetAuthorizationURL(ctx context.Context, requestToken, redirect
 		return "", errors.New("RedirectUrl is empty")
 	}
 
-	return fmt.Sprintf(authorizeURL, requestToken, redirectUrl), nil
+	query := url.Values{}
+	query.Set("request_token", requestToken)
+	query.Set("redirect_uri", redirectUrl)
+
+	if err := applyAuthURLOptions(opts).query(query); err != nil {
+		return "", err
+	}
+
+	return authorizeURL + "?" + query.Encode(), nil
 }
 
-func (c *Client) Add(ctx context.Context, input AddInput) error {
-	if err := input.validate(); err != nil {
-		return err
+// Add saves input.URL and returns the item Pocket created for it, so the
+// item ID is at hand for follow-up actions. opts are applied on top of
+// input. AddURL is usually more readable.
+func (c *Client) Add(ctx context.Context, input AddInput, opts ...AddOption) (*AddedItem, error) {
+	for _, opt := range opts {
+		opt(&input)
+	}
+
+	input.AccessToken = c.token(input.AccessToken)
+	input, err := input.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if input.skipIfExists {
+		item, err := c.GetItemByURL(ctx, input.AccessToken, input.URL)
+		if err == nil {
+			return nil, &AlreadySavedError{Item: *item}
+		}
+		if !errors.Is(err, ErrItemNotFound) {
+			return nil, err
+		}
 	}
 
 	inp := input.generateRequest(c.consumerKey)
 
-	_, err := c.doHTTP(ctx, endpointAdd, inp)
+	var resp addResponse
+	err = c.doStream(ctx, endpointAdd, inp, func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(&resp)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	return err
+	if resp.Status != 1 {
+		return nil, fmt.Errorf("Add failed with status %d", resp.Status)
+	}
+
+	if resp.Item == nil || resp.Item.ItemID == "" {
+		return nil, errors.New("Empty item in API response")
+	}
+
+	return resp.Item, nil
 }
 
+// AddBatchActions saves many URLs through add actions on the send endpoint,
+// in chunks of up to maxSendActions. Every input is checked before anything
+// is sent; AccessToken on the inputs is ignored in favour of accessToken.
+// Results are merged into one response indexed like inputs, and failures in
+// any chunk are reported as a single *BatchError.
+func (c *Client) AddBatchActions(ctx context.Context, accessToken string, inputs []AddInput, opts ...ActionOption) (*SendResponse, error) {
+	actions := make([]Action, 0, len(inputs))
+	for i, input := range inputs {
+		action, err := newAddAction(input)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i, err)
+		}
+		actions = append(actions, action)
+	}
+
+	return c.sendChunked(ctx, accessToken, actions, opts)
+}
+
+// GetAccessToken exchanges an authorized request token for an access token.
+// It is GetAuthorizeResponse without the username.
 func (c *Client) GetAccessToken(ctx context.Context, requestToken string) (string, error) {
+	resp, err := c.GetAuthorizeResponse(ctx, requestToken)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.AccessToken, nil
+}
+
+// GetAuthorizeResponse exchanges an authorized request token for an access
+// token and returns it together with the username of the account.
+func (c *Client) GetAuthorizeResponse(ctx context.Context, requestToken string) (*AuthorizeResponse, error) {
 	if requestToken == "" {
-		return "", errors.New("RequestToken is empty")
+		return nil, errors.New("RequestToken is empty")
 	}
 
 	inp := accessTokenRequest{
@@ -156,52 +417,107 @@ func (c *Client) GetAccessToken(ctx context.Context, requestToken string) (strin
 		Code:        requestToken,
 	}
 
-	values, err := c.doHTTP(ctx, endpointAuthorize, inp)
+	var resp AuthorizeResponse
+	err := c.doJSONOrForm(ctx, endpointAuthorize, inp, &resp, func(values url.Values) {
+		resp.AccessToken = values.Get("access_token")
+		resp.Username = values.Get("username")
+	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	accessToken := values.Get("access_token")
-	if accessToken == "" {
-		return "", errors.New("Empty access token in API response")
+	if resp.AccessToken == "" {
+		return nil, errors.New("Empty access token in API response")
 	}
 
-	return accessToken, nil
+	return &resp, nil
+}
+
+// doJSONOrForm asks for a JSON response and decodes it into dst. The OAuth
+// endpoints answer form-encoded when the header is lost on the way, so such
+// a response is parsed and handed to form instead.
+func (c *Client) doJSONOrForm(ctx context.Context, endpoint string, body interface{}, dst interface{}, form func(url.Values)) error {
+	header := http.Header{}
+	header.Set(xAcceptHeader, "application/json")
+
+	return c.doRequest(ctx, endpoint, body, header, func(r io.Reader) error {
+		respB, err := io.ReadAll(r)
+		if err != nil {
+			return errors.Join(err, errors.New("Failed read response"))
+		}
+
+		if trimmed := bytes.TrimSpace(respB); len(trimmed) > 0 && trimmed[0] == '{' {
+			if err := json.Unmarshal(trimmed, dst); err != nil {
+				return errors.Join(err, errors.New("Failed to decode response"))
+			}
+			return nil
+		}
+
+		values, err := url.ParseQuery(string(respB))
+		if err != nil {
+			return errors.Join(err, errors.New("Failed to parse response values"))
+		}
+		form(values)
+
+		return nil
+	})
+}
+
+// doStream asks for a JSON response and hands the body to decode without
+// buffering it first.
+func (c *Client) doStream(ctx context.Context, endpoint string, body interface{}, decode func(io.Reader) error) error {
+	header := http.Header{}
+	header.Set(xAcceptHeader, "application/json")
+
+	return c.doRequest(ctx, endpoint, body, header, func(r io.Reader) error {
+		if err := decode(r); err != nil {
+			return errors.Join(err, errors.New("Failed to decode response"))
+		}
+
+		return nil
+	})
 }
 
-func (c *Client) doHTTP(ctx context.Context, endpoint string, body interface{}) (url.Values, error) {
-	b, err := json.Marshal(body)
+func (c *Client) doRequest(ctx context.Context, endpoint string, body interface{}, header http.Header, handle func(io.Reader) error) error {
+	payload, err := newRequestBody(body)
 	if err != nil {
-		return url.Values{}, errors.Join(err, errors.New("Failed to marshal body"))
+		return errors.Join(err, errors.New("Failed to marshal body"))
 	}
+	defer payload.release()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, host+endpoint, bytes.NewBuffer(b))
+	reader, err := payload.reader()
 	if err != nil {
-		return url.Values{}, errors.Join(err, errors.New("Failed to create request"))
+		return err
 	}
 
-	req.Header.Add("Content-Type", "application/json; charset=UTF8")
-
-	resp, err := c.client.Do(req)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, host+endpoint, reader)
 	if err != nil {
-		return url.Values{}, errors.Join(err, errors.New("Failed to send http request..."))
+		reader.Close()
+		return errors.Join(err, errors.New("Failed to create request"))
 	}
-	defer resp.Body.Close()
+	req.ContentLength = payload.len()
+	req.GetBody = payload.reader
 
-	if resp.StatusCode != http.StatusOK {
-		err := fmt.Sprintf("API Error : %v", resp.Header.Get(xErrorHeader))
-		return url.Values{}, errors.New(err)
+	for k, v := range header {
+		req.Header[k] = v
 	}
+	req.Header.Add("Content-Type", "application/json; charset=UTF8")
 
-	respB, err := io.ReadAll(resp.Body)
+	resp, err := c.client.Do(req)
 	if err != nil {
-		return url.Values{}, errors.Join(err, errors.New("Failed read response"))
+		return errors.Join(err, errors.New("Failed to send http request..."))
 	}
+	defer resp.Body.Close()
 
-	values, err := url.ParseQuery(string(respB))
-	if err != nil {
-		return url.Values{}, errors.Join(err, errors.New("Failed to parse response values"))
+	if resp.StatusCode != http.StatusOK {
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Code:       resp.Header.Get(xErrorCodeHeader),
+			Message:    resp.Header.Get(xErrorHeader),
+			Header:     resp.Header,
+			endpoint:   endpoint,
+		}
 	}
 
-	return values, nil
+	return handle(resp.Body)
 }
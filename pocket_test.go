@@ -2,10 +2,15 @@ package pocket
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"io"
 	"net/http"
+	"net/url"
+	"os"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -16,12 +21,22 @@ func (s roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
 }
 
 func newClient(t *testing.T, statusCode int, path string, body string) *Client {
+	return newClientWithCheck(t, statusCode, path, body, nil)
+}
+
+// newClientWithCheck is like newClient but also hands every outgoing request
+// to check, so tests can assert on headers and the request body.
+func newClientWithCheck(t *testing.T, statusCode int, path string, body string, check func(r *http.Request)) *Client {
 	return &Client{
 		client: &http.Client{
 			Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
 				assert.Equal(t, path, r.URL.Path)
 				assert.Equal(t, http.MethodPost, r.Method)
 
+				if check != nil {
+					check(r)
+				}
+
 				return &http.Response{
 					StatusCode: statusCode,
 					Body:       io.NopCloser(strings.NewReader(body)),
@@ -32,6 +47,72 @@ func newClient(t *testing.T, statusCode int, path string, body string) *Client {
 	}
 }
 
+// newScriptedClient serves responses in order, one per request, and fails
+// the test if more requests are made than scripted. Request bodies are
+// appended to the returned slice.
+func newScriptedClient(t *testing.T, path string, responses ...string) (*Client, *[]string) {
+	var (
+		mu     sync.Mutex
+		bodies []string
+	)
+
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		assert.Equal(t, path, r.URL.Path)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(bodies) >= len(responses) {
+			t.Errorf("unexpected request #%d", len(bodies)+1)
+			return &http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader(""))}, nil
+		}
+
+		resp := responses[len(bodies)]
+		bodies = append(bodies, readBody(t, r))
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(resp)),
+		}, nil
+	})
+
+	return &Client{client: &http.Client{Transport: transport}, consumerKey: "key"}, &bodies
+}
+
+// newRoutedClient answers every request with the response scripted for its
+// path and records the paths and bodies of the requests, in order.
+func newRoutedClient(t *testing.T, responses map[string]string) (*Client, *[][2]string) {
+	var (
+		mu       sync.Mutex
+		requests [][2]string
+	)
+
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		requests = append(requests, [2]string{r.URL.Path, readBody(t, r)})
+
+		resp, ok := responses[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			return &http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader(""))}, nil
+		}
+
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(resp))}, nil
+	})
+
+	return &Client{client: &http.Client{Transport: transport}, consumerKey: "key"}, &requests
+}
+
+// readBody returns the JSON request body of r.
+func readBody(t *testing.T, r *http.Request) string {
+	b, err := io.ReadAll(r.Body)
+	assert.NoError(t, err)
+
+	return string(b)
+}
+
 func TestClient_GetAccessToken(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -49,6 +130,14 @@ func TestClient_GetAccessToken(t *testing.T) {
 			want:         "qwe-rty-123",
 			wantErr:      false,
 		},
+		{
+			name:         "JSON-OK",
+			requestToken: "12345-qwerty",
+			response:     `{"access_token":"qwe-rty-123","username":"pocketuser"}`,
+			statusCode:   200,
+			want:         "qwe-rty-123",
+			wantErr:      false,
+		},
 		{
 			name:    "Empty requestToken",
 			wantErr: true,
@@ -83,6 +172,60 @@ func TestClient_GetAccessToken(t *testing.T) {
 	}
 }
 
+func TestClient_GetAuthorizeResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     *AuthorizeResponse
+		wantErr  bool
+	}{
+		{
+			name:     "JSON",
+			response: `{"access_token":"qwe-rty-123","username":"pocketuser"}`,
+			want:     &AuthorizeResponse{AccessToken: "qwe-rty-123", Username: "pocketuser"},
+		},
+		{
+			name:     "Form",
+			response: "access_token=qwe-rty-123&username=pocket%2Buser%40example.com",
+			want:     &AuthorizeResponse{AccessToken: "qwe-rty-123", Username: "pocket+user@example.com"},
+		},
+		{
+			name:     "JSON without username",
+			response: ` {"access_token":"qwe-rty-123"}`,
+			want:     &AuthorizeResponse{AccessToken: "qwe-rty-123"},
+		},
+		{
+			name:     "JSON without token",
+			response: `{"username":"pocketuser"}`,
+			wantErr:  true,
+		},
+		{
+			name:     "Broken JSON",
+			response: `{"access_token":`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClientWithCheck(t, http.StatusOK, "/v3/oauth/authorize", tt.response, func(r *http.Request) {
+				assert.Equal(t, "application/json", r.Header.Get("X-Accept"))
+				assert.Equal(t, `{"consumer_key":"key","code":"12345-qwerty"}`, readBody(t, r))
+			})
+
+			got, err := client.GetAuthorizeResponse(context.Background(), "12345-qwerty")
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestClient_GetRequestToken(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -135,38 +278,224 @@ func TestClient_GetRequestToken(t *testing.T) {
 
 }
 
+func TestClient_GetRequestTokenResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     *RequestTokenResponse
+	}{
+		{
+			name:     "JSON",
+			response: `{"code":"qwe-rty-123","state":"s1"}`,
+			want:     &RequestTokenResponse{Code: "qwe-rty-123", State: "s1"},
+		},
+		{
+			name:     "Form",
+			response: "code=qwe-rty-123&state=s1",
+			want:     &RequestTokenResponse{Code: "qwe-rty-123", State: "s1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClientWithCheck(t, 200, "/v3/oauth/request", tt.response, func(r *http.Request) {
+				assert.JSONEq(t, `{"consumer_key":"key","redirect_uri":"https://localhost","state":"s1"}`, readBody(t, r))
+			})
+
+			got, err := client.GetRequestTokenResponse(context.Background(), "https://localhost", WithOAuthState("s1"))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, VerifyState("s1", got.State))
+		})
+	}
+}
+
+func TestVerifyState(t *testing.T) {
+	assert.NoError(t, VerifyState("abc", "abc"))
+	assert.ErrorIs(t, VerifyState("abc", "abd"), ErrStateMismatch)
+	assert.ErrorIs(t, VerifyState("abc", ""), ErrStateMismatch)
+	assert.ErrorIs(t, VerifyState("abc", "abcd"), ErrStateMismatch)
+
+	var verr *ValidationError
+	assert.ErrorAs(t, VerifyState("", ""), &verr)
+
+	a, err := NewState()
+	assert.NoError(t, err)
+	b, err := NewState()
+	assert.NoError(t, err)
+	assert.Len(t, a, 43)
+	assert.NotEqual(t, a, b)
+}
+
+func TestClient_GetAuthorizationURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		requestToken string
+		redirectUrl  string
+		opts         []AuthURLOption
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:         "Plain",
+			requestToken: "dcba4321-dcba-4321-dcba-4321dc",
+			redirectUrl:  "https://example.com/cb",
+			want:         "https://getpocket.com/auth/authorize?redirect_uri=https%3A%2F%2Fexample.com%2Fcb&request_token=dcba4321-dcba-4321-dcba-4321dc",
+		},
+		{
+			name:         "Redirect with query",
+			requestToken: "code",
+			redirectUrl:  "https://example.com/cb?source=bot&x=1",
+			want:         "https://getpocket.com/auth/authorize?redirect_uri=https%3A%2F%2Fexample.com%2Fcb%3Fsource%3Dbot%26x%3D1&request_token=code",
+		},
+		{
+			name:         "Redirect with spaces",
+			requestToken: "code",
+			redirectUrl:  "myapp://auth done",
+			want:         "https://getpocket.com/auth/authorize?redirect_uri=myapp%3A%2F%2Fauth+done&request_token=code",
+		},
+		{
+			name:         "Non-ASCII redirect",
+			requestToken: "code",
+			redirectUrl:  "https://пример.рф/обратно",
+			want:         "https://getpocket.com/auth/authorize?redirect_uri=https%3A%2F%2F%D0%BF%D1%80%D0%B8%D0%BC%D0%B5%D1%80.%D1%80%D1%84%2F%D0%BE%D0%B1%D1%80%D0%B0%D1%82%D0%BD%D0%BE&request_token=code",
+		},
+		{
+			name:         "Token needing escapes",
+			requestToken: "a&b=c",
+			redirectUrl:  "https://example.com/",
+			want:         "https://getpocket.com/auth/authorize?redirect_uri=https%3A%2F%2Fexample.com%2F&request_token=a%26b%3Dc",
+		},
+		{
+			name:         "Mobile",
+			requestToken: "code",
+			redirectUrl:  "https://t.me/pocketbot?start=auth",
+			opts:         []AuthURLOption{WithMobile()},
+			want:         "https://getpocket.com/auth/authorize?mobile=1&redirect_uri=https%3A%2F%2Ft.me%2Fpocketbot%3Fstart%3Dauth&request_token=code",
+		},
+		{
+			name:         "Force login",
+			requestToken: "code",
+			redirectUrl:  "https://example.com/",
+			opts:         []AuthURLOption{WithForceLogin()},
+			want:         "https://getpocket.com/auth/authorize?force=login&redirect_uri=https%3A%2F%2Fexample.com%2F&request_token=code",
+		},
+		{
+			name:         "Every option",
+			requestToken: "code",
+			redirectUrl:  "https://example.com/",
+			opts:         []AuthURLOption{WithMobile(), WithForceLogin(), WithPermissions(PermissionRetrieve, PermissionModify)},
+			want:         "https://getpocket.com/auth/authorize?force=login&mobile=1&permissions=retrieve%2Cmodify&redirect_uri=https%3A%2F%2Fexample.com%2F&request_token=code",
+		},
+		{
+			name:         "No permissions",
+			requestToken: "code",
+			redirectUrl:  "https://example.com/",
+			opts:         []AuthURLOption{WithPermissions()},
+			wantErr:      true,
+		},
+		{
+			name:         "Unknown permission",
+			requestToken: "code",
+			redirectUrl:  "https://example.com/",
+			opts:         []AuthURLOption{WithPermissions("delete")},
+			wantErr:      true,
+		},
+		{
+			name:         "Empty permission",
+			requestToken: "code",
+			redirectUrl:  "https://example.com/",
+			opts:         []AuthURLOption{WithPermissions(PermissionAdd, "")},
+			wantErr:      true,
+		},
+		{
+			name:         "Repeated permission",
+			requestToken: "code",
+			redirectUrl:  "https://example.com/",
+			opts:         []AuthURLOption{WithMobile(), WithPermissions(PermissionAdd, PermissionRetrieve, PermissionAdd)},
+			want:         "https://getpocket.com/auth/authorize?mobile=1&permissions=add%2Cretrieve&redirect_uri=https%3A%2F%2Fexample.com%2F&request_token=code",
+		},
+		{
+			name:        "Empty request token",
+			redirectUrl: "https://example.com/",
+			wantErr:     true,
+		},
+		{
+			name:         "Empty redirect",
+			requestToken: "code",
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t, http.StatusOK, "", "")
+
+			got, err := client.GetAuthorizationURL(context.Background(), tt.requestToken, tt.redirectUrl, tt.opts...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			// The redirect survives a round trip through the query.
+			u, err := url.Parse(got)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.redirectUrl, u.Query().Get("redirect_uri"))
+			assert.Equal(t, tt.requestToken, u.Query().Get("request_token"))
+		})
+	}
+}
+
 func TestClient_Add(t *testing.T) {
 	tests := []struct {
 		name       string
 		input      AddInput
 		statusCode int
+		wantBody   string
 		wantErr    bool
 	}{
 		{
 			name: "Default-OK",
 			input: AddInput{
-				URL:         "some_url.com",
+				URL:         "https://some_url.com",
 				Title:       "some_title",
 				Tags:        []string{"some_tag_1", "some_tag_2"},
 				AccessToken: "access-to-ken",
 			},
 			statusCode: 200,
+			wantBody:   `{"url":"https://some_url.com","title":"some_title","tags":"some_tag_1,some_tag_2","consumer_key":"key","access_token":"access-to-ken"}`,
 			wantErr:    false,
 		},
 		{
 			name: "Default-EmptyTags-OK",
 			input: AddInput{
-				URL:         "some_url.com",
+				URL:         "https://some_url.com",
 				Title:       "some_title",
 				AccessToken: "access-to-ken",
 			},
 			statusCode: 200,
+			wantBody:   `{"url":"https://some_url.com","title":"some_title","consumer_key":"key","access_token":"access-to-ken"}`,
+			wantErr:    false,
+		},
+		{
+			// No title, so Pocket keeps the one it resolves from the page.
+			name: "URL only",
+			input: AddInput{
+				URL:         "https://some_url.com",
+				Tags:        []string{" ", ""},
+				AccessToken: "access-to-ken",
+			},
+			statusCode: 200,
+			wantBody:   `{"url":"https://some_url.com","consumer_key":"key","access_token":"access-to-ken"}`,
 			wantErr:    false,
 		},
 		{
 			name: "Empty accessToken",
 			input: AddInput{
-				URL:         "some_url.com",
+				URL:         "https://some_url.com",
 				Title:       "some_title",
 				Tags:        []string{"some_tag_1", "some_tag_2"},
 				AccessToken: "",
@@ -189,15 +518,373 @@ func TestClient_Add(t *testing.T) {
 		},
 	}
 
+	response, err := os.ReadFile("testdata/add.json")
+	assert.NoError(t, err)
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			client := newClient(t, tt.statusCode, "/v3/add", "")
+			var body string
+			client := newClientWithCheck(t, tt.statusCode, "/v3/add", string(response), func(r *http.Request) {
+				assert.Equal(t, "application/json", r.Header.Get("X-Accept"))
+				body = readBody(t, r)
+			})
 
-			err := client.Add(context.Background(), tt.input)
+			item, err := client.Add(context.Background(), tt.input)
 			if tt.wantErr {
 				assert.Error(t, err)
+				assert.Nil(t, item)
+				assert.Empty(t, body)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.wantBody, body)
+				assert.Equal(t, &AddedItem{
+					ItemID:      "229279689",
+					ResolvedID:  "229279689",
+					GivenURL:    "https://example.com/articles/go-generics?utm_source=feed",
+					NormalURL:   "http://example.com/articles/go-generics",
+					ResolvedURL: "https://example.com/articles/go-generics",
+					Title:       "Generics in Go",
+					Excerpt:     "Type parameters arrive & change how we write Go.",
+					Lang:        "en",
+					WordCount:   1234,
+					IsArticle:   true,
+					HasImage:    MediaContains,
+					HasVideo:    MediaNone,
+				}, item)
+			}
+		})
+	}
+}
+
+func TestCleanURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawurl  string
+		want    string
+		wantErr bool
+	}{
+		{name: "Plain", rawurl: "https://example.com/a", want: "https://example.com/a"},
+		{name: "Surrounding whitespace", rawurl: "  https://example.com/a\n", want: "https://example.com/a"},
+		{name: "Fragment", rawurl: "https://example.com/a#section-2", want: "https://example.com/a#section-2"},
+		{name: "Fragment route", rawurl: "https://example.com/#/route?x=1", want: "https://example.com/#/route?x=1"},
+		{name: "Fragment with space", rawurl: "https://example.com/a#two words", want: "https://example.com/a#two%20words"},
+		{name: "IDN host", rawurl: "https://bücher.de/", want: "https://bücher.de/"},
+		{name: "IDN host with path", rawurl: "https://例え.jp/パス", want: "https://例え.jp/%E3%83%91%E3%82%B9"},
+		{name: "Punycode host", rawurl: "https://xn--bcher-kva.de/", want: "https://xn--bcher-kva.de/"},
+		{name: "Space in path", rawurl: "http://example.com/a b", want: "http://example.com/a%20b"},
+		{name: "Unsafe query", rawurl: "https://example.com/?q=a b|c&r=ä", want: "https://example.com/?q=a%20b%7Cc&r=%C3%A4"},
+		{name: "Escapes kept", rawurl: "https://example.com/a%20b?q=x%26y", want: "https://example.com/a%20b?q=x%26y"},
+		{name: "Port and user", rawurl: "https://me@example.com:8443/a", want: "https://me@example.com:8443/a"},
+		{name: "Empty", rawurl: " ", wantErr: true},
+		{name: "Words", rawurl: "hello world", wantErr: true},
+		{name: "No scheme", rawurl: "example.com/a", wantErr: true},
+		{name: "Other scheme", rawurl: "ftp://example.com/a", wantErr: true},
+		{name: "Mailto", rawurl: "mailto:me@example.com", wantErr: true},
+		{name: "No host", rawurl: "https:///a", wantErr: true},
+		{name: "Space in host", rawurl: "https://exa mple.com/", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cleanURL(tt.rawurl)
+			if tt.wantErr {
+				var verr *ValidationError
+				if assert.ErrorAs(t, err, &verr) {
+					assert.Equal(t, "URL", verr.Field)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_Add_CleansURL(t *testing.T) {
+	var body map[string]any
+	client := newClientWithCheck(t, http.StatusOK, "/v3/add", `{"item":{"item_id":"1"},"status":1}`, func(r *http.Request) {
+		assert.NoError(t, json.Unmarshal([]byte(readBody(t, r)), &body))
+	})
+
+	_, err := client.Add(context.Background(), AddInput{URL: " https://bücher.de/a b#top ", AccessToken: "access-to-ken"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://bücher.de/a%20b#top", body["url"])
+}
+
+func TestClient_Add_Attribution(t *testing.T) {
+	tests := []struct {
+		name    string
+		tweetID string
+		refID   string
+		want    map[string]any
+	}{
+		{
+			name: "Without attribution",
+			want: map[string]any{"url": "https://example.com", "consumer_key": "key", "access_token": "access-to-ken"},
+		},
+		{
+			name:    "With tweet",
+			tweetID: "1453256458236891136",
+			want:    map[string]any{"url": "https://example.com", "tweet_id": "1453256458236891136", "consumer_key": "key", "access_token": "access-to-ken"},
+		},
+		{
+			name:  "With ref",
+			refID: "229279689",
+			want:  map[string]any{"url": "https://example.com", "ref_id": "229279689", "consumer_key": "key", "access_token": "access-to-ken"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]any
+			client := newClientWithCheck(t, http.StatusOK, "/v3/add", `{"item":{"item_id":"1"},"status":1}`, func(r *http.Request) {
+				assert.NoError(t, json.Unmarshal([]byte(readBody(t, r)), &body))
+			})
+
+			_, err := client.Add(context.Background(), AddInput{URL: "https://example.com", AccessToken: "access-to-ken", TweetID: tt.tweetID, RefID: tt.refID})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, body)
+		})
+	}
+}
+
+func TestClient_Add_BadResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+	}{
+		{name: "Status 0", response: `{"item":{"item_id":"1"},"status":0}`},
+		{name: "No item", response: `{"status":1}`},
+		{name: "Null item", response: `{"item":null,"status":1}`},
+		{name: "Query string", response: `item_id=1&status=1`},
+		{name: "Invalid item", response: `{"item":{"item_id":"1","word_count":"many"},"status":1}`},
+	}
+
+	input := AddInput{URL: "https://example.com", AccessToken: "access-to-ken"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newClient(t, http.StatusOK, "/v3/add", tt.response)
+
+			item, err := client.Add(context.Background(), input)
+			assert.Error(t, err)
+			assert.Nil(t, item)
+		})
+	}
+}
+
+func TestClient_WithAccessToken(t *testing.T) {
+	client, requests := newRoutedClient(t, map[string]string{
+		"/v3/add":  `{"item":{"item_id":"1"},"status":1}`,
+		"/v3/get":  `{"status":1,"list":{}}`,
+		"/v3/send": `{"status":1,"action_results":[true]}`,
+	})
+	user := client.WithAccessToken("user-token")
+	ctx := context.Background()
+
+	_, err := user.AddURL(ctx, "https://example.com/")
+	assert.NoError(t, err)
+	_, err = user.Get(ctx, GetInput{})
+	assert.NoError(t, err)
+	assert.NoError(t, user.Archive(ctx, "", "1"))
+	_, err = user.AddURL(ctx, "https://example.com/", WithAccessToken("other-token"))
+	assert.NoError(t, err)
+
+	tokens := make([]string, len(*requests))
+	for i, request := range *requests {
+		var body struct {
+			AccessToken string `json:"access_token"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(request[1]), &body))
+		tokens[i] = body.AccessToken
+	}
+	assert.Equal(t, []string{"user-token", "user-token", "user-token", "other-token"}, tokens)
+
+	// The parent client is left without a token.
+	var verr *ValidationError
+	_, err = client.AddURL(ctx, "https://example.com/")
+	assert.ErrorAs(t, err, &verr)
+	_, err = client.Get(ctx, GetInput{})
+	assert.ErrorAs(t, err, &verr)
+	assert.ErrorAs(t, client.Archive(ctx, "", "1"), &verr)
+	assert.Len(t, *requests, 4)
+}
+
+func TestClient_ValidateToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		header  http.Header
+		wantIs  error
+		wantErr bool
+	}{
+		{name: "Valid", status: http.StatusOK},
+		{
+			name:    "Invalid",
+			status:  http.StatusUnauthorized,
+			header:  http.Header{"X-Error": {"Invalid access token"}, "X-Error-Code": {"107"}},
+			wantIs:  ErrInvalidToken,
+			wantErr: true,
+		},
+		{
+			name:    "Revoked",
+			status:  http.StatusForbidden,
+			header:  http.Header{"X-Error": {"User authorization failed"}, "X-Error-Code": {"107"}},
+			wantIs:  ErrTokenRevoked,
+			wantErr: true,
+		},
+		{
+			name:    "Rate limited",
+			status:  http.StatusForbidden,
+			header:  http.Header{"X-Error": {"User rate limit exceeded"}, "X-Limit-User-Remaining": {"0"}},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid consumer key",
+			status:  http.StatusForbidden,
+			header:  http.Header{"X-Error": {"Invalid consumer key."}, "X-Error-Code": {"152"}},
+			wantErr: true,
+		},
+		{
+			name:    "Server error",
+			status:  http.StatusServiceUnavailable,
+			header:  http.Header{"X-Error": {"Pocket server issue."}, "X-Error-Code": {"199"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				client: &http.Client{
+					Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+						assert.Equal(t, "/v3/get", r.URL.Path)
+						assert.JSONEq(t, `{"consumer_key":"key","access_token":"tok","count":1,"detailType":"simple"}`, readBody(t, r))
+
+						return &http.Response{
+							StatusCode: tt.status,
+							Header:     tt.header,
+							Body:       io.NopCloser(strings.NewReader(`{"status":1,"list":{}}`)),
+						}, nil
+					}),
+				},
+				consumerKey: "key",
+			}
+
+			err := client.ValidateToken(context.Background(), "tok")
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			var apiErr *APIError
+			assert.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tt.status, apiErr.StatusCode)
+
+			for _, sentinel := range []error{ErrInvalidToken, ErrTokenRevoked} {
+				assert.Equal(t, sentinel == tt.wantIs, errors.Is(err, sentinel), sentinel)
+			}
+		})
+	}
+}
+
+func TestAPIError_Classification(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		status int
+		header http.Header
+		want   error
+	}{
+		{
+			name:   "Revoked",
+			path:   "/v3/send",
+			status: http.StatusForbidden,
+			header: http.Header{"X-Error": {"User authorization failed"}, "X-Error-Code": {"107"}, "X-Limit-User-Remaining": {"9999"}},
+			want:   ErrTokenRevoked,
+		},
+		{
+			name:   "Other forbidden",
+			path:   "/v3/send",
+			status: http.StatusForbidden,
+			header: http.Header{"X-Error": {"Access denied"}, "X-Limit-User-Remaining": {"9999"}},
+		},
+		{
+			name:   "Invalid token",
+			path:   "/v3/send",
+			status: http.StatusUnauthorized,
+			header: http.Header{"X-Error": {"Access token is invalid"}, "X-Error-Code": {"107"}},
+			want:   ErrInvalidToken,
+		},
+		{
+			name:   "User rate limit",
+			path:   "/v3/send",
+			status: http.StatusForbidden,
+			header: http.Header{"X-Error": {"User rate limit exceeded"}, "X-Limit-User-Remaining": {"0"}, "X-Limit-User-Reset": {"3600"}},
+			want:   ErrRateLimited,
+		},
+		{
+			name:   "Key rate limit",
+			path:   "/v3/send",
+			status: http.StatusForbidden,
+			header: http.Header{"X-Error": {"Consumer key rate limit exceeded"}, "X-Limit-User-Remaining": {"120"}, "X-Limit-Key-Remaining": {"0"}},
+			want:   ErrRateLimited,
+		},
+		{
+			name:   "Too many requests",
+			path:   "/v3/send",
+			status: http.StatusTooManyRequests,
+			header: http.Header{"X-Error": {"Too many requests"}},
+			want:   ErrRateLimited,
+		},
+		{
+			name:   "Invalid consumer key",
+			path:   "/v3/send",
+			status: http.StatusForbidden,
+			header: http.Header{"X-Error": {"Invalid consumer key."}, "X-Error-Code": {"152"}},
+		},
+		{
+			name:   "Server error",
+			path:   "/v3/send",
+			status: http.StatusServiceUnavailable,
+			header: http.Header{"X-Error": {"Pocket server issue."}, "X-Error-Code": {"199"}},
+		},
+		{
+			name:   "Rejected request token",
+			path:   "/v3/oauth/authorize",
+			status: http.StatusForbidden,
+			header: http.Header{"X-Error": {"User rejected code."}, "X-Error-Code": {"158"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{
+				client: &http.Client{
+					Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+						assert.Equal(t, tt.path, r.URL.Path)
+
+						return &http.Response{StatusCode: tt.status, Header: tt.header, Body: io.NopCloser(strings.NewReader(""))}, nil
+					}),
+				},
+				consumerKey: "key",
+			}
+
+			var err error
+			if tt.path == "/v3/oauth/authorize" {
+				_, err = client.GetAuthorizeResponse(context.Background(), "code")
+			} else {
+				_, err = client.Send(context.Background(), "tok", []Action{{Action: ActionArchive, ItemID: "1"}})
+			}
+
+			var apiErr *APIError
+			if assert.ErrorAs(t, err, &apiErr) {
+				assert.Equal(t, tt.header.Get("X-Error"), apiErr.Message)
+			}
+			assert.Contains(t, err.Error(), tt.header.Get("X-Error"))
+
+			for _, sentinel := range []error{ErrInvalidToken, ErrTokenRevoked, ErrRateLimited} {
+				assert.Equal(t, sentinel == tt.want, errors.Is(err, sentinel), sentinel)
 			}
 		})
 	}
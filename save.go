@@ -0,0 +1,96 @@
+package pocket
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// SaveResult tells which way Save went.
+type SaveResult struct {
+	// Created is set when the URL was not saved yet and has been added;
+	// Added then holds the new item. Otherwise Existing holds the saved item
+	// as it is after the update.
+	Created  bool
+	ItemID   ItemID
+	Added    *AddedItem
+	Existing *Item
+}
+
+// Save adds input.URL like Add, unless it is already saved: then its tags
+// are added to the existing item's instead, and with WithReadd the item is
+// moved back to the unread list. Saving the same URL twice is thus
+// harmless and accumulates tags. The lookup matches URLs like GetItemByURL.
+//
+// When updating a saved item fails, Save returns an *ActionError wrapping
+// the *BatchError together with the result, whose Existing reflects the
+// updates that did apply.
+func (c *Client) Save(ctx context.Context, input AddInput, opts ...AddOption) (*SaveResult, error) {
+	for _, opt := range opts {
+		opt(&input)
+	}
+
+	input.AccessToken = c.token(input.AccessToken)
+	input, err := input.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := c.findItemByURL(ctx, input.AccessToken, input.URL, DetailComplete)
+	if errors.Is(err, ErrItemNotFound) {
+		added, err := c.Add(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		return &SaveResult{Created: true, ItemID: added.ItemID, Added: added}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []Action
+	if len(input.Tags) > 0 {
+		actions = append(actions, Action{Action: ActionTagsAdd, ItemID: item.ItemID, Tags: input.Tags})
+	}
+	if input.readd {
+		actions = append(actions, Action{Action: ActionReadd, ItemID: item.ItemID})
+	}
+
+	if len(actions) == 0 {
+		return &SaveResult{ItemID: item.ItemID, Existing: item}, nil
+	}
+
+	resp, err := c.Send(ctx, input.AccessToken, actions)
+
+	var berr *BatchError
+	if err != nil && !errors.As(err, &berr) {
+		return nil, err
+	}
+
+	if !resp.DryRun {
+		for i, action := range actions {
+			if berr != nil && slices.Contains(berr.Failed, i) {
+				continue
+			}
+
+			switch action.Action {
+			case ActionTagsAdd:
+				item.Tags, _ = sanitizeTags(append(item.Tags, action.Tags...))
+			case ActionReadd:
+				item.Status = ItemStatusUnread
+			}
+		}
+	}
+
+	result := &SaveResult{ItemID: item.ItemID, Existing: item}
+	if berr != nil {
+		failed := actions[0]
+		if len(berr.Actions) > 0 {
+			failed = berr.Actions[0]
+		}
+		return result, actionError(failed, berr)
+	}
+
+	return result, nil
+}
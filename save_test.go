@@ -0,0 +1,132 @@
+package pocket
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Save(t *testing.T) {
+	const saved = `{"status":1,"list":{"9":{"item_id":"9","given_url":"https://lwn.net/Articles/1/","status":"1","sort_id":0,` +
+		`"tags":{"linux":{"item_id":"9","tag":"linux"}}}}}`
+
+	tests := []struct {
+		name       string
+		get        string
+		rawurl     string
+		opts       []AddOption
+		wantPaths  []string
+		wantSend   string
+		wantResult *SaveResult
+	}{
+		{
+			name:      "New URL",
+			get:       `{"status":1,"list":[]}`,
+			rawurl:    "https://lwn.net/Articles/2/",
+			opts:      []AddOption{WithTags("kernel")},
+			wantPaths: []string{"/v3/get", "/v3/add"},
+			wantResult: &SaveResult{
+				Created: true,
+				ItemID:  "10",
+				Added:   &AddedItem{ItemID: "10"},
+			},
+		},
+		{
+			name:      "Existing gets tags added",
+			get:       saved,
+			rawurl:    "http://www.lwn.net/Articles/1",
+			opts:      []AddOption{WithTags("kernel", "Linux")},
+			wantPaths: []string{"/v3/get", "/v3/send"},
+			wantSend:  `"actions":[{"action":"tags_add","item_id":"9","tags":"kernel,Linux"}]`,
+			wantResult: &SaveResult{
+				ItemID:   "9",
+				Existing: &Item{ItemID: "9", GivenURL: "https://lwn.net/Articles/1/", Status: ItemStatusArchived, Tags: []string{"linux", "kernel"}},
+			},
+		},
+		{
+			name:      "Existing readded",
+			get:       saved,
+			rawurl:    "https://lwn.net/Articles/1/",
+			opts:      []AddOption{WithTags("kernel"), WithReadd()},
+			wantPaths: []string{"/v3/get", "/v3/send"},
+			wantSend:  `"actions":[{"action":"tags_add","item_id":"9","tags":"kernel"},{"action":"readd","item_id":"9"}]`,
+			wantResult: &SaveResult{
+				ItemID:   "9",
+				Existing: &Item{ItemID: "9", GivenURL: "https://lwn.net/Articles/1/", Status: ItemStatusUnread, Tags: []string{"linux", "kernel"}},
+			},
+		},
+		{
+			name:      "Existing without tags",
+			get:       saved,
+			rawurl:    "https://lwn.net/Articles/1/",
+			wantPaths: []string{"/v3/get"},
+			wantResult: &SaveResult{
+				ItemID:   "9",
+				Existing: &Item{ItemID: "9", GivenURL: "https://lwn.net/Articles/1/", Status: ItemStatusArchived, Tags: []string{"linux"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, requests := newRoutedClient(t, map[string]string{
+				"/v3/get":  tt.get,
+				"/v3/add":  `{"item":{"item_id":"10"},"status":1}`,
+				"/v3/send": `{"status":1,"action_results":[true,true]}`,
+			})
+
+			got, err := client.Save(context.Background(), AddInput{URL: tt.rawurl, AccessToken: "access-to-ken"}, tt.opts...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantResult, got)
+
+			var paths []string
+			for _, r := range *requests {
+				paths = append(paths, r[0])
+				if r[0] == "/v3/get" {
+					assert.Contains(t, r[1], `"detailType":"complete"`)
+				}
+				if r[0] == "/v3/send" {
+					assert.Contains(t, r[1], tt.wantSend)
+				}
+			}
+			assert.Equal(t, tt.wantPaths, paths)
+		})
+	}
+}
+
+func TestClient_Save_Rejected(t *testing.T) {
+	client, _ := newRoutedClient(t, map[string]string{
+		"/v3/get":  `{"status":1,"list":{"9":{"item_id":"9","given_url":"https://lwn.net/","status":"1","sort_id":0}}}`,
+		"/v3/send": `{"status":0,"action_results":[false,true]}`,
+	})
+
+	got, err := client.Save(context.Background(), AddInput{URL: "https://lwn.net/", AccessToken: "access-to-ken", Tags: []string{"go"}}, WithReadd())
+
+	var aerr *ActionError
+	if assert.ErrorAs(t, err, &aerr) {
+		assert.Equal(t, ActionTagsAdd, aerr.Action)
+		assert.Equal(t, ItemID("9"), aerr.ItemID)
+
+		var berr *BatchError
+		if assert.ErrorAs(t, err, &berr) {
+			assert.Equal(t, []int{0}, berr.Failed)
+		}
+	}
+
+	// The item was found and readded; only the tags are missing.
+	assert.Equal(t, &SaveResult{
+		ItemID:   "9",
+		Existing: &Item{ItemID: "9", GivenURL: "https://lwn.net/", Status: ItemStatusUnread},
+	}, got)
+}
+
+func TestClient_Save_Invalid(t *testing.T) {
+	client := newClient(t, http.StatusOK, "/v3/get", "")
+
+	_, err := client.Save(context.Background(), AddInput{URL: "lwn.net", AccessToken: "access-to-ken"})
+
+	var verr *ValidationError
+	assert.ErrorAs(t, err, &verr)
+}
@@ -0,0 +1,317 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const endpointSend = "/send"
+
+// maxSendActions bounds how many actions the bulk helpers put in one send
+// request.
+const maxSendActions = 100
+
+// ActionType names a modify operation accepted by the send endpoint.
+type ActionType string
+
+const (
+	ActionAdd         ActionType = "add"
+	ActionArchive     ActionType = "archive"
+	ActionReadd       ActionType = "readd"
+	ActionFavorite    ActionType = "favorite"
+	ActionUnfavorite  ActionType = "unfavorite"
+	ActionDelete      ActionType = "delete"
+	ActionTagsAdd     ActionType = "tags_add"
+	ActionTagsRemove  ActionType = "tags_remove"
+	ActionTagsReplace ActionType = "tags_replace"
+	ActionTagsClear   ActionType = "tags_clear"
+	ActionTagRename   ActionType = "tag_rename"
+	ActionTagDelete   ActionType = "tag_delete"
+)
+
+// Action is a single modify operation. Which fields apply depends on Action:
+// item actions need ItemID, add needs URL, tag_rename needs OldTag and
+// NewTag, and tag_delete needs Tag.
+type Action struct {
+	Action ActionType
+	ItemID ItemID
+	URL    string
+	Title  string
+	Tags   []string
+	// RefID attributes an add to the item it came from.
+	RefID  string
+	Tag    string
+	OldTag string
+	NewTag string
+	// Time is when the action happened; Pocket uses the request time when it
+	// is zero. It is sent as UTC unix seconds, so its location does not
+	// matter.
+	Time time.Time
+}
+
+type (
+	// sendAction is the wire form of an Action.
+	sendAction struct {
+		Action ActionType `json:"action"`
+		ItemID ItemID     `json:"item_id,omitempty"`
+		URL    string     `json:"url,omitempty"`
+		Title  string     `json:"title,omitempty"`
+		Tags   string     `json:"tags,omitempty"`
+		RefID  string     `json:"ref_id,omitempty"`
+		Tag    string     `json:"tag,omitempty"`
+		OldTag string     `json:"old_tag,omitempty"`
+		NewTag string     `json:"new_tag,omitempty"`
+		Time   int64      `json:"time,omitempty"`
+	}
+
+	sendRequest struct {
+		ConsumerKey string   `json:"consumer_key"`
+		AccessToken string   `json:"access_token"`
+		Actions     []Action `json:"actions"`
+	}
+
+	SendResponse struct {
+		// Status is 1 when Pocket processed the batch.
+		Status int
+		// Results holds one entry per submitted action, in order.
+		Results []ActionResult
+		// DryRun is set when nothing was sent because of WithDryRun or
+		// Client.DryRun. Status and Results are then empty and Actions
+		// holds what would have been sent.
+		DryRun  bool
+		Actions []Action
+		// Raw is the response body as received, for fields the SDK does not
+		// model. It is nil on responses merged from several requests.
+		Raw json.RawMessage
+	}
+
+	// ActionResult is the outcome of the action at Index in the batch.
+	ActionResult struct {
+		Index int
+		OK    bool
+		// Item is the saved item for a successful add action, when Pocket
+		// reported it.
+		Item *AddedItem
+		// Raw is the entry of action_results as received when it is an
+		// object rather than a plain true or false.
+		Raw json.RawMessage
+	}
+)
+
+// MarshalJSON emits only the fields the action type uses, since Pocket
+// rejects actions carrying irrelevant keys even when they are empty. Action
+// types the SDK does not know keep every non-empty field.
+func (a Action) MarshalJSON() ([]byte, error) {
+	w := sendAction{Action: a.Action, Time: unixSeconds(a.Time)}
+	tags := strings.Join(a.Tags, ",")
+
+	switch a.Action {
+	case ActionAdd:
+		w.URL, w.Title, w.Tags, w.RefID = a.URL, a.Title, tags, a.RefID
+	case ActionArchive, ActionReadd, ActionFavorite, ActionUnfavorite, ActionDelete, ActionTagsClear:
+		w.ItemID = a.ItemID
+	case ActionTagsAdd, ActionTagsRemove, ActionTagsReplace:
+		w.ItemID, w.Tags = a.ItemID, tags
+	case ActionTagRename:
+		w.OldTag, w.NewTag = a.OldTag, a.NewTag
+	case ActionTagDelete:
+		w.Tag = a.Tag
+	default:
+		w = sendAction{
+			Action: a.Action,
+			ItemID: a.ItemID,
+			URL:    a.URL,
+			Title:  a.Title,
+			Tags:   tags,
+			RefID:  a.RefID,
+			Tag:    a.Tag,
+			OldTag: a.OldTag,
+			NewTag: a.NewTag,
+			Time:   w.Time,
+		}
+	}
+
+	return json.Marshal(w)
+}
+
+// Send submits actions in a single request to the send endpoint, which
+// applies them in order. When Pocket rejects the batch or any action in it,
+// the response is returned together with a *BatchError naming the failures.
+// Of the action options only WithDryRun applies.
+func (c *Client) Send(ctx context.Context, accessToken string, actions []Action, opts ...ActionOption) (*SendResponse, error) {
+	accessToken = c.token(accessToken)
+	if err := validateSend(accessToken, actions); err != nil {
+		return nil, err
+	}
+
+	if c.dryRunning(opts) {
+		return &SendResponse{DryRun: true, Actions: actions}, nil
+	}
+
+	inp := sendRequest{
+		ConsumerKey: c.consumerKey,
+		AccessToken: accessToken,
+		Actions:     actions,
+	}
+
+	var resp SendResponse
+	err := c.doStream(ctx, endpointSend, inp, func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(&resp)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if berr := batchError(actions, &resp); berr != nil {
+		return &resp, berr
+	}
+
+	return &resp, nil
+}
+
+func validateSend(accessToken string, actions []Action) error {
+	if accessToken == "" {
+		return errNoAccessToken()
+	}
+
+	if len(actions) == 0 {
+		return &ValidationError{Field: "actions", Reason: "is empty"}
+	}
+
+	for i, action := range actions {
+		if action.Action == "" {
+			return &ValidationError{Field: fmt.Sprintf("actions[%d].Action", i), Reason: "is empty"}
+		}
+	}
+
+	return nil
+}
+
+// batchError collects the actions without a successful result. Per-action
+// results are trusted where present, since Pocket reports status 0 as soon
+// as one action fails; a status other than 1 is an error either way.
+func batchError(actions []Action, resp *SendResponse) *BatchError {
+	berr := &BatchError{Response: resp}
+	for i, action := range actions {
+		if i < len(resp.Results) && resp.Results[i].OK {
+			continue
+		}
+
+		berr.Failed = append(berr.Failed, i)
+		berr.Actions = append(berr.Actions, action)
+	}
+
+	if len(berr.Failed) == 0 && resp.Status == 1 {
+		return nil
+	}
+
+	return berr
+}
+
+func (r *SendResponse) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Status        int               `json:"status"`
+		ActionResults []json.RawMessage `json:"action_results"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	r.Status = aux.Status
+	r.Raw = append(json.RawMessage(nil), data...)
+	r.Results = make([]ActionResult, len(aux.ActionResults))
+	for i, raw := range aux.ActionResults {
+		result, err := decodeActionResult(raw)
+		if err != nil {
+			return fmt.Errorf("action_results[%d]: %w", i, err)
+		}
+		result.Index = i
+		r.Results[i] = result
+	}
+
+	return nil
+}
+
+// decodeActionResult decodes one entry of action_results, which is a bool
+// for most actions and the saved item object for a successful add.
+func decodeActionResult(raw json.RawMessage) (ActionResult, error) {
+	switch string(raw) {
+	case "true":
+		return ActionResult{OK: true}, nil
+	case "false", "null":
+		return ActionResult{}, nil
+	}
+
+	if len(raw) == 0 || raw[0] != '{' {
+		return ActionResult{}, fmt.Errorf("unexpected value %s", raw)
+	}
+
+	var item AddedItem
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return ActionResult{}, err
+	}
+
+	return ActionResult{OK: true, Item: &item, Raw: raw}, nil
+}
+
+// sendChunked sends actions in requests of at most maxSendActions and merges
+// the responses, with results and BatchError indexes relative to actions.
+// Rejected actions do not stop later chunks; any other error does, and is
+// returned with the results gathered so far.
+func (c *Client) sendChunked(ctx context.Context, accessToken string, actions []Action, opts []ActionOption) (*SendResponse, error) {
+	if c.dryRunning(opts) {
+		return c.Send(ctx, accessToken, actions, opts...)
+	}
+
+	if len(actions) == 0 {
+		return nil, &ValidationError{Field: "actions", Reason: "is empty"}
+	}
+
+	merged := &SendResponse{Status: 1, Results: make([]ActionResult, 0, len(actions))}
+	for start := 0; start < len(actions); start += maxSendActions {
+		chunk := actions[start:min(start+maxSendActions, len(actions))]
+
+		resp, err := c.Send(ctx, accessToken, chunk, opts...)
+
+		var berr *BatchError
+		if err != nil && !errors.As(err, &berr) {
+			return merged, err
+		}
+
+		if resp.Status != 1 {
+			merged.Status = resp.Status
+		}
+
+		// Keep indexes aligned even if Pocket returned too few results.
+		for i := range chunk {
+			var result ActionResult
+			if i < len(resp.Results) {
+				result = resp.Results[i]
+			}
+			result.Index = start + i
+			merged.Results = append(merged.Results, result)
+		}
+	}
+
+	if berr := batchError(actions, merged); berr != nil {
+		return merged, berr
+	}
+
+	return merged, nil
+}
+
+// RetryFailed sends the actions batchErr reports as failed again, in their
+// original order and with their original times. A *BatchError returned from
+// here indexes into batchErr.Actions, so it can be retried in turn.
+func (c *Client) RetryFailed(ctx context.Context, accessToken string, batchErr *BatchError, opts ...ActionOption) (*SendResponse, error) {
+	if batchErr == nil || len(batchErr.Actions) == 0 {
+		return nil, &ValidationError{Field: "batchErr", Reason: "has no failed actions"}
+	}
+
+	return c.sendChunked(ctx, accessToken, batchErr.Actions, opts)
+}
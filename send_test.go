@@ -0,0 +1,598 @@
+package pocket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Send(t *testing.T) {
+	const response = `{"status":1,"action_results":[true,{"item_id":"9","normal_url":"http://example.com/"},true]}`
+
+	var body string
+	client := newClientWithCheck(t, http.StatusOK, "/v3/send", response,
+		func(r *http.Request) {
+			assert.Equal(t, "application/json", r.Header.Get("X-Accept"))
+			body = readBody(t, r)
+		})
+
+	got, err := client.Send(context.Background(), "access-to-ken", []Action{
+		{Action: ActionArchive, ItemID: "1", Time: time.Unix(1700000000, 0)},
+		{Action: ActionAdd, URL: "https://example.com", Title: "Example", Tags: []string{"go", "read"}},
+		{Action: ActionTagRename, OldTag: "golang", NewTag: "go"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &SendResponse{
+		Status: 1,
+		Results: []ActionResult{
+			{Index: 0, OK: true},
+			{
+				Index: 1,
+				OK:    true,
+				Item:  &AddedItem{ItemID: "9", NormalURL: "http://example.com/"},
+				Raw:   json.RawMessage(`{"item_id":"9","normal_url":"http://example.com/"}`),
+			},
+			{Index: 2, OK: true},
+		},
+		Raw: json.RawMessage(response),
+	}, got)
+	assert.JSONEq(t, `{
+		"consumer_key":"key",
+		"access_token":"access-to-ken",
+		"actions":[
+			{"action":"archive","item_id":"1","time":1700000000},
+			{"action":"add","url":"https://example.com","title":"Example","tags":"go,read"},
+			{"action":"tag_rename","old_tag":"golang","new_tag":"go"}
+		]
+	}`, body)
+}
+
+func TestClient_Send_BatchError(t *testing.T) {
+	actions := []Action{
+		{Action: ActionArchive, ItemID: "1"},
+		{Action: ActionFavorite, ItemID: "2"},
+		{Action: ActionTagsClear, ItemID: "3"},
+	}
+
+	tests := []struct {
+		name       string
+		response   string
+		wantFailed []int
+		wantErr    string
+	}{
+		{name: "All succeeded", response: `{"status":1,"action_results":[true,true,true]}`},
+		{name: "All failed", response: `{"status":0,"action_results":[false,false,false]}`, wantFailed: []int{0, 1, 2}, wantErr: "3 actions failed, first archive at index 0"},
+		{name: "Mixed", response: `{"status":0,"action_results":[true,false,true]}`, wantFailed: []int{1}, wantErr: "1 actions failed, first favorite at index 1"},
+		{name: "Mixed with status 1", response: `{"status":1,"action_results":[true,true,false]}`, wantFailed: []int{2}, wantErr: "1 actions failed, first tags_clear at index 2"},
+		{name: "Status 0 without results", response: `{"status":0}`, wantFailed: []int{0, 1, 2}, wantErr: "3 actions failed, first archive at index 0"},
+		{name: "Short results", response: `{"status":1,"action_results":[true]}`, wantFailed: []int{1, 2}, wantErr: "2 actions failed, first favorite at index 1"},
+		{name: "Status 0 with every result true", response: `{"status":0,"action_results":[true,true,true]}`, wantErr: "send failed with status 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newScriptedClient(t, "/v3/send", tt.response)
+
+			resp, err := client.Send(context.Background(), "access-to-ken", actions)
+			if !assert.NotNil(t, resp) {
+				return
+			}
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.wantErr)
+
+			var berr *BatchError
+			if assert.ErrorAs(t, err, &berr) {
+				assert.Equal(t, tt.wantFailed, berr.Failed)
+				assert.Same(t, resp, berr.Response)
+
+				var want []Action
+				for _, i := range tt.wantFailed {
+					want = append(want, actions[i])
+				}
+				assert.Equal(t, want, berr.Actions)
+			}
+		})
+	}
+}
+
+func TestClient_Send_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		accessToken string
+		actions     []Action
+		field       string
+	}{
+		{name: "Empty access token", actions: []Action{{Action: ActionArchive, ItemID: "1"}}, field: "AccessToken"},
+		{name: "No actions", accessToken: "access-to-ken", field: "actions"},
+		{name: "Unnamed action", accessToken: "access-to-ken", actions: []Action{{Action: ActionArchive, ItemID: "1"}, {ItemID: "2"}}, field: "actions[1].Action"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, bodies := newScriptedClient(t, "/v3/send")
+
+			_, err := client.Send(context.Background(), tt.accessToken, tt.actions)
+
+			var verr *ValidationError
+			if assert.ErrorAs(t, err, &verr) {
+				assert.Equal(t, tt.field, verr.Field)
+			}
+			assert.Empty(t, *bodies)
+		})
+	}
+}
+
+func TestClient_Send_APIError(t *testing.T) {
+	client := newClient(t, http.StatusBadRequest, "/v3/send", "")
+
+	_, err := client.Send(context.Background(), "access-to-ken", []Action{{Action: ActionArchive, ItemID: "1"}})
+	assert.Error(t, err)
+}
+
+func TestSendResponse_UnmarshalJSON(t *testing.T) {
+	b, err := os.ReadFile("testdata/send_mixed.json")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var got SendResponse
+	if !assert.NoError(t, json.Unmarshal(b, &got)) {
+		return
+	}
+
+	assert.JSONEq(t, string(b), string(got.Raw))
+	got.Raw = nil
+
+	var raws []string
+	for i, result := range got.Results {
+		raws = append(raws, string(result.Raw))
+		got.Results[i].Raw = nil
+	}
+	if assert.Len(t, raws, 5) {
+		assert.Equal(t, []string{"", "", ""}, []string{raws[0], raws[2], raws[4]})
+		assert.Contains(t, raws[1], `"domain_id": "40131"`)
+		assert.JSONEq(t, `{"item_id":"3012345678","normal_url":"http://example.com/pending","resolved_id":"0","given_url":"https://example.com/pending"}`, raws[3])
+	}
+
+	assert.Equal(t, SendResponse{Status: 1, Results: []ActionResult{
+		{Index: 0, OK: true},
+		{Index: 1, OK: true, Item: &AddedItem{
+			ItemID:      "229279689",
+			ResolvedID:  "229279689",
+			GivenURL:    "http://ideashower.com/ideas/launched/pocket-and-instapaper/",
+			NormalURL:   "http://ideashower.com/ideas/launched/pocket-and-instapaper/",
+			ResolvedURL: "https://ideashower.com/ideas/launched/pocket-and-instapaper/",
+			Title:       "Pocket &amp; Instapaper",
+			Excerpt:     `Saving "later" reads`,
+			Lang:        "en",
+			WordCount:   468,
+			IsArticle:   true,
+			HasImage:    MediaContains,
+		}},
+		{Index: 2, OK: false},
+		{Index: 3, OK: true, Item: &AddedItem{
+			ItemID:     "3012345678",
+			ResolvedID: "0",
+			GivenURL:   "https://example.com/pending",
+			NormalURL:  "http://example.com/pending",
+		}},
+		{Index: 4, OK: true},
+	}}, got)
+}
+
+func TestSendResponse_UnmarshalJSON_Invalid(t *testing.T) {
+	tests := []string{
+		`{"status":1,"action_results":["yes"]}`,
+		`{"status":1,"action_results":[1]}`,
+		`{"status":1,"action_results":[{"item_id":"1","word_count":"many"}]}`,
+	}
+
+	for _, data := range tests {
+		var got SendResponse
+		assert.Error(t, json.Unmarshal([]byte(data), &got), data)
+	}
+}
+
+func TestClient_AddBatchActions(t *testing.T) {
+	inputs := make([]AddInput, 250)
+	for i := range inputs {
+		inputs[i] = AddInput{URL: fmt.Sprintf("https://example.com/%d", i)}
+	}
+	inputs[0].Title = "First"
+	inputs[0].Tags = []string{" go", "Go", "import"}
+	inputs[0].Time = time.Unix(1300000000, 0)
+
+	results := func(n int, failed ...int) string {
+		r := make([]string, n)
+		for i := range r {
+			r[i] = "true"
+		}
+		for _, i := range failed {
+			r[i] = "false"
+		}
+		return `{"status":1,"action_results":[` + strings.Join(r, ",") + `]}`
+	}
+
+	client, bodies := newScriptedClient(t, "/v3/send",
+		results(100),
+		results(100, 5),
+		results(50, 49),
+	)
+
+	resp, err := client.AddBatchActions(context.Background(), "access-to-ken", inputs)
+
+	var berr *BatchError
+	if assert.ErrorAs(t, err, &berr) {
+		assert.Equal(t, []int{105, 249}, berr.Failed)
+		assert.Equal(t, "https://example.com/105", berr.Actions[0].URL)
+	}
+	if assert.NotNil(t, resp) && assert.Len(t, resp.Results, 250) {
+		assert.Equal(t, ActionResult{Index: 105}, resp.Results[105])
+		assert.Equal(t, ActionResult{Index: 248, OK: true}, resp.Results[248])
+	}
+
+	if assert.Len(t, *bodies, 3) {
+		var req struct {
+			Actions []json.RawMessage `json:"actions"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte((*bodies)[0]), &req))
+		if assert.Len(t, req.Actions, 100) {
+			assert.JSONEq(t, `{"action":"add","url":"https://example.com/0","title":"First","tags":"go,import","time":1300000000}`, string(req.Actions[0]))
+			assert.JSONEq(t, `{"action":"add","url":"https://example.com/1"}`, string(req.Actions[1]))
+		}
+
+		assert.NoError(t, json.Unmarshal([]byte((*bodies)[2]), &req))
+		assert.Len(t, req.Actions, 50)
+	}
+}
+
+func TestClient_AddBatchActions_Invalid(t *testing.T) {
+	inputs := []AddInput{
+		{URL: "https://example.com/0"},
+		{URL: "https://example.com/1"},
+		{URL: " "},
+	}
+
+	client, bodies := newScriptedClient(t, "/v3/send")
+
+	_, err := client.AddBatchActions(context.Background(), "access-to-ken", inputs)
+	assert.EqualError(t, err, "input 2: invalid URL: is empty")
+	assert.Empty(t, *bodies)
+
+	_, err = client.AddBatchActions(context.Background(), "access-to-ken", nil)
+	var verr *ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Empty(t, *bodies)
+}
+
+func TestClient_AddBatchActions_TransportError(t *testing.T) {
+	inputs := make([]AddInput, 150)
+	for i := range inputs {
+		inputs[i] = AddInput{URL: fmt.Sprintf("https://example.com/%d", i)}
+	}
+
+	var calls int
+	client := &Client{
+		client: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 2 {
+				return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{"X-Error": {"Down"}}, Body: io.NopCloser(strings.NewReader(""))}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"status":1,"action_results":[` + strings.Repeat("true,", 99) + `true]}`))}, nil
+		})},
+		consumerKey: "key",
+	}
+
+	resp, err := client.AddBatchActions(context.Background(), "access-to-ken", inputs)
+	assert.EqualError(t, err, "API Error : Down")
+	if assert.NotNil(t, resp) {
+		assert.Len(t, resp.Results, 100)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient_RetryFailed(t *testing.T) {
+	at := time.Unix(1500000000, 0)
+	actions := []Action{
+		{Action: ActionAdd, URL: "https://example.com/a", Time: at},
+		{Action: ActionAdd, URL: "https://example.com/b", Time: at},
+		{Action: ActionArchive, ItemID: "3", Time: at},
+		{Action: ActionAdd, URL: "https://example.com/d"},
+	}
+
+	client, bodies := newScriptedClient(t, "/v3/send",
+		`{"status":0,"action_results":[{"item_id":"1"},false,true,false]}`,
+		`{"status":0,"action_results":[false,{"item_id":"4"}]}`,
+		`{"status":1,"action_results":[{"item_id":"2"}]}`,
+	)
+
+	_, err := client.Send(context.Background(), "access-to-ken", actions)
+	var berr *BatchError
+	if !assert.ErrorAs(t, err, &berr) {
+		return
+	}
+	assert.Equal(t, []int{1, 3}, berr.Failed)
+
+	_, err = client.RetryFailed(context.Background(), "access-to-ken", berr)
+	if !assert.ErrorAs(t, err, &berr) {
+		return
+	}
+	assert.Equal(t, []int{0}, berr.Failed)
+
+	resp, err := client.RetryFailed(context.Background(), "access-to-ken", berr)
+	assert.NoError(t, err)
+	assert.Equal(t, &SendResponse{Status: 1, Results: []ActionResult{
+		{Index: 0, OK: true, Item: &AddedItem{ItemID: "2"}, Raw: json.RawMessage(`{"item_id":"2"}`)},
+	}}, resp)
+
+	if assert.Len(t, *bodies, 3) {
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","actions":[
+			{"action":"add","url":"https://example.com/b","time":1500000000},
+			{"action":"add","url":"https://example.com/d"}
+		]}`, (*bodies)[1])
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","actions":[
+			{"action":"add","url":"https://example.com/b","time":1500000000}
+		]}`, (*bodies)[2])
+	}
+}
+
+func TestClient_RetryFailed_Nothing(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/send")
+
+	for _, berr := range []*BatchError{nil, {Response: &SendResponse{}}} {
+		_, err := client.RetryFailed(context.Background(), "access-to-ken", berr)
+		var verr *ValidationError
+		assert.ErrorAs(t, err, &verr)
+	}
+	assert.Empty(t, *bodies)
+}
+
+func TestAction_MarshalJSON(t *testing.T) {
+	// Every field is set so the test shows which ones each type drops.
+	full := func(action ActionType) Action {
+		return Action{
+			Action: action,
+			ItemID: "42",
+			URL:    "https://example.com",
+			Title:  "Title",
+			Tags:   []string{"go", "read"},
+			RefID:  "99",
+			Tag:    "tag",
+			OldTag: "old",
+			NewTag: "new",
+			Time:   time.Unix(1600000000, 0),
+		}
+	}
+
+	tests := []struct {
+		action ActionType
+		want   string
+	}{
+		{ActionAdd, `{"action":"add","url":"https://example.com","title":"Title","tags":"go,read","ref_id":"99","time":1600000000}`},
+		{ActionArchive, `{"action":"archive","item_id":"42","time":1600000000}`},
+		{ActionReadd, `{"action":"readd","item_id":"42","time":1600000000}`},
+		{ActionFavorite, `{"action":"favorite","item_id":"42","time":1600000000}`},
+		{ActionUnfavorite, `{"action":"unfavorite","item_id":"42","time":1600000000}`},
+		{ActionDelete, `{"action":"delete","item_id":"42","time":1600000000}`},
+		{ActionTagsAdd, `{"action":"tags_add","item_id":"42","tags":"go,read","time":1600000000}`},
+		{ActionTagsRemove, `{"action":"tags_remove","item_id":"42","tags":"go,read","time":1600000000}`},
+		{ActionTagsReplace, `{"action":"tags_replace","item_id":"42","tags":"go,read","time":1600000000}`},
+		{ActionTagsClear, `{"action":"tags_clear","item_id":"42","time":1600000000}`},
+		{ActionTagRename, `{"action":"tag_rename","old_tag":"old","new_tag":"new","time":1600000000}`},
+		{ActionTagDelete, `{"action":"tag_delete","tag":"tag","time":1600000000}`},
+		{"future", `{"action":"future","item_id":"42","url":"https://example.com","title":"Title","tags":"go,read","ref_id":"99","tag":"tag","old_tag":"old","new_tag":"new","time":1600000000}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.action), func(t *testing.T) {
+			got, err := json.Marshal(full(tt.action))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestAction_MarshalJSON_Empty(t *testing.T) {
+	tests := []struct {
+		action Action
+		want   string
+	}{
+		{Action{Action: ActionAdd, URL: "https://example.com"}, `{"action":"add","url":"https://example.com"}`},
+		{Action{Action: ActionArchive, ItemID: "1"}, `{"action":"archive","item_id":"1"}`},
+		{Action{Action: ActionTagsAdd, ItemID: "1", Tags: []string{}}, `{"action":"tags_add","item_id":"1"}`},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.action)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, string(got))
+	}
+}
+
+func TestClient_Send_DryRun(t *testing.T) {
+	actions := []Action{{Action: ActionArchive, ItemID: "1"}, {Action: ActionDelete, ItemID: "2"}}
+	client, bodies := newScriptedClient(t, "/v3/send")
+
+	resp, err := client.Send(context.Background(), "access-to-ken", actions, WithDryRun())
+	assert.NoError(t, err)
+	assert.Equal(t, &SendResponse{DryRun: true, Actions: actions}, resp)
+
+	resp, err = client.DryRun().Send(context.Background(), "access-to-ken", actions)
+	assert.NoError(t, err)
+	assert.True(t, resp.DryRun)
+
+	_, err = client.Send(context.Background(), "", actions, WithDryRun())
+	var verr *ValidationError
+	assert.ErrorAs(t, err, &verr)
+
+	assert.NoError(t, client.Archive(context.Background(), "access-to-ken", "1", WithDryRun()))
+	assert.ErrorAs(t, client.Archive(context.Background(), "access-to-ken", "x", WithDryRun()), &verr)
+
+	resp, err = client.AddBatchActions(context.Background(), "access-to-ken", make([]AddInput, 0), WithDryRun())
+	assert.ErrorAs(t, err, &verr)
+	assert.Nil(t, resp)
+
+	assert.Empty(t, *bodies)
+	assert.False(t, client.dryRun)
+}
+
+func TestSendResponse_Raw(t *testing.T) {
+	b, err := os.ReadFile("testdata/send_errors.json")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var got SendResponse
+	if !assert.NoError(t, json.Unmarshal(b, &got)) {
+		return
+	}
+	assert.Equal(t, []ActionResult{{Index: 0}, {Index: 1, OK: true}}, got.Results)
+
+	// Fields the SDK does not model stay reachable.
+	var extra struct {
+		ActionErrors []*struct {
+			Message string `json:"message"`
+			Code    int    `json:"code"`
+		} `json:"action_errors"`
+	}
+	if assert.NoError(t, json.Unmarshal(got.Raw, &extra)) && assert.Len(t, extra.ActionErrors, 2) {
+		assert.Equal(t, "Invalid item id", extra.ActionErrors[0].Message)
+		assert.Equal(t, 422, extra.ActionErrors[0].Code)
+		assert.Nil(t, extra.ActionErrors[1])
+	}
+}
+
+func TestClient_Send_GetBody(t *testing.T) {
+	const response = `{"status":1,"action_results":[true]}`
+
+	client := newClientWithCheck(t, http.StatusOK, "/v3/send", response,
+		func(r *http.Request) {
+			body := readBody(t, r)
+			assert.NoError(t, r.Body.Close())
+			assert.Equal(t, int64(len(body)), r.ContentLength)
+
+			// A retry must see the same payload even after the first body
+			// was consumed and closed.
+			if assert.NotNil(t, r.GetBody) {
+				replay, err := r.GetBody()
+				assert.NoError(t, err)
+				assert.Equal(t, body, readBody(t, &http.Request{Body: replay}))
+				assert.NoError(t, replay.Close())
+			}
+		})
+
+	_, err := client.Send(context.Background(), "access-to-ken", []Action{{Action: ActionArchive, ItemID: "1"}})
+	assert.NoError(t, err)
+}
+
+func BenchmarkSendBody(b *testing.B) {
+	actions := make([]Action, 5000)
+	for i := range actions {
+		actions[i] = Action{Action: ActionTagsAdd, ItemID: ItemID(fmt.Sprint(i + 1)), Tags: []string{"go", "reading"}}
+	}
+	inp := sendRequest{ConsumerKey: "key", AccessToken: "access-to-ken", Actions: actions}
+
+	// marshal mirrors the previous approach: a fresh slice per request
+	// wrapped in a buffer.
+	b.Run("marshal", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			raw, err := json.Marshal(inp)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := io.Copy(io.Discard, bytes.NewBuffer(raw)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			payload, err := newRequestBody(inp)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			r, err := payload.reader()
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := io.Copy(io.Discard, r); err != nil {
+				b.Fatal(err)
+			}
+			r.Close()
+			payload.release()
+		}
+	})
+}
+
+func TestClient_Send_GetBodyAfterRelease(t *testing.T) {
+	var req *http.Request
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		req = r
+		r.Body.Close()
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"status":1,"action_results":[true]}`))}, nil
+	})
+	client := &Client{client: &http.Client{Transport: transport}, consumerKey: "key"}
+
+	assert.NoError(t, client.Archive(context.Background(), "access-to-ken", "1"))
+	if !assert.NotNil(t, req) {
+		return
+	}
+
+	// A transport retrying after the request returned must not read a
+	// buffer that is back in the pool.
+	body, err := req.GetBody()
+	assert.ErrorIs(t, err, errBodyReleased)
+	assert.Nil(t, body)
+}
+
+func TestClient_Archive_ActionTimeLocation(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	assert.NoError(t, err)
+
+	var body string
+	client := newClientWithCheck(t, http.StatusOK, "/v3/send", `{"status":1,"action_results":[true]}`,
+		func(r *http.Request) {
+			body = readBody(t, r)
+		})
+
+	// 23:00 in Sydney on the 15th is noon UTC, not 23:00 UTC.
+	at := time.Date(2024, 1, 15, 23, 0, 0, 0, sydney)
+	assert.NoError(t, client.Archive(context.Background(), "access-to-ken", "1", WithActionTime(at)))
+	assert.Contains(t, body, `{"action":"archive","item_id":"1","time":1705320000}`)
+}
+
+func TestClient_AddBatchActions_RefID(t *testing.T) {
+	var body string
+	client := newClientWithCheck(t, http.StatusOK, "/v3/send", `{"status":1,"action_results":[true,true]}`,
+		func(r *http.Request) {
+			body = readBody(t, r)
+		})
+
+	_, err := client.AddBatchActions(context.Background(), "access-to-ken", []AddInput{
+		{URL: "https://example.com/a", RefID: "1234"},
+		{URL: "https://example.com/b"},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, body, `{"action":"add","url":"https://example.com/a","ref_id":"1234"}`)
+	assert.Contains(t, body, `{"action":"add","url":"https://example.com/b"}`)
+}
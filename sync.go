@@ -0,0 +1,64 @@
+package pocket
+
+import (
+	"context"
+	"time"
+)
+
+// SyncResult classifies everything that changed since a checkpoint. Since is
+// the server's checkpoint to pass to the next Sync call.
+type SyncResult struct {
+	Added    []Item
+	Updated  []Item
+	Archived []Item
+	Deleted  []Item
+	Since    time.Time
+}
+
+// Sync fetches every change since the given checkpoint. A zero since performs
+// a full initial sync in which every unread item counts as added.
+//
+// Deleted items come back with status 2 and little more than their item_id,
+// so only ItemID is reliable on Deleted entries.
+func (c *Client) Sync(ctx context.Context, accessToken string, since time.Time) (*SyncResult, error) {
+	accessToken = c.token(accessToken)
+	if accessToken == "" {
+		return nil, errNoAccessToken()
+	}
+
+	input := GetInput{
+		AccessToken: accessToken,
+		State:       StateAll,
+		Detail:      DetailSimple,
+		Since:       since,
+	}
+
+	result := &SyncResult{}
+	for resp, err := range c.Pages(ctx, input) {
+		if err != nil {
+			return nil, err
+		}
+
+		result.Since = resp.Since
+		for _, item := range resp.Items {
+			result.classify(item, since)
+		}
+	}
+
+	return result, nil
+}
+
+func (r *SyncResult) classify(item Item, since time.Time) {
+	switch {
+	case item.IsDeleted():
+		r.Deleted = append(r.Deleted, item)
+	case item.IsArchived():
+		r.Archived = append(r.Archived, item)
+	default:
+		if since.IsZero() || !item.TimeAdded.Before(since) {
+			r.Added = append(r.Added, item)
+		} else {
+			r.Updated = append(r.Updated, item)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package pocket
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Sync(t *testing.T) {
+	since := time.Unix(1471869000, 0).UTC()
+
+	tests := []struct {
+		name         string
+		since        time.Time
+		responses    []string
+		wantAdded    []string
+		wantUpdated  []string
+		wantArchived []string
+		wantDeleted  []string
+		wantSince    time.Time
+		wantErr      bool
+	}{
+		{
+			name:  "Delta-OK",
+			since: since,
+			responses: []string{`{"status":1,"list":{` +
+				`"1":{"item_id":"1","status":"0","time_added":"1471869500","time_updated":"1471869500","sort_id":0},` +
+				`"2":{"item_id":"2","status":"0","time_added":"1400000000","time_updated":"1471869600","sort_id":1},` +
+				`"3":{"item_id":"3","status":"1","time_added":"1400000000","time_read":"1471869700","sort_id":2},` +
+				`"4":{"item_id":"4","status":"2","sort_id":3}` +
+				`},"since":1471870000}`},
+			wantAdded:    []string{"1"},
+			wantUpdated:  []string{"2"},
+			wantArchived: []string{"3"},
+			wantDeleted:  []string{"4"},
+			wantSince:    time.Unix(1471870000, 0).UTC(),
+		},
+		{
+			name: "Initial-OK",
+			responses: []string{`{"status":1,"list":{` +
+				`"1":{"item_id":"1","status":"0","time_added":"1400000000","sort_id":0},` +
+				`"2":{"item_id":"2","status":"1","time_added":"1400000000","sort_id":1}` +
+				`},"since":1471870000}`},
+			wantAdded:    []string{"1"},
+			wantArchived: []string{"2"},
+			wantSince:    time.Unix(1471870000, 0).UTC(),
+		},
+		{
+			name:  "Nothing changed",
+			since: since,
+			responses: []string{
+				`{"status":2,"list":{},"since":1471870000}`,
+			},
+			wantSince: time.Unix(1471870000, 0).UTC(),
+		},
+		{
+			name:      "API error",
+			since:     since,
+			responses: []string{`oops`},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newScriptedClient(t, "/v3/get", tt.responses...)
+
+			got, err := client.Sync(context.Background(), "access-to-ken", tt.since)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantAdded, idsOrNil(got.Added))
+			assert.Equal(t, tt.wantUpdated, idsOrNil(got.Updated))
+			assert.Equal(t, tt.wantArchived, idsOrNil(got.Archived))
+			assert.Equal(t, tt.wantDeleted, idsOrNil(got.Deleted))
+			assert.Equal(t, tt.wantSince, got.Since)
+		})
+	}
+}
+
+func TestClient_Sync_RequestBody(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/get", `{"status":1,"list":{},"since":1471870000}`)
+
+	_, err := client.Sync(context.Background(), "access-to-ken", time.Unix(1471869000, 0))
+	assert.NoError(t, err)
+	if assert.Len(t, *bodies, 1) {
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","count":30,"state":"all","detailType":"simple","since":1471869000}`, (*bodies)[0])
+	}
+}
+
+func TestClient_Sync_EmptyAccessToken(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/get")
+
+	_, err := client.Sync(context.Background(), "", time.Time{})
+	assert.Error(t, err)
+	assert.Empty(t, *bodies)
+}
+
+func idsOrNil(items []Item) []string {
+	if len(items) == 0 {
+		return nil
+	}
+
+	return itemIDs(items)
+}
@@ -0,0 +1,258 @@
+package pocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// TagRename renames a tag on every item carrying it, in one action.
+func (c *Client) TagRename(ctx context.Context, accessToken, oldTag, newTag string, opts ...ActionOption) error {
+	action, err := newTagRenameAction(oldTag, newTag)
+	if err != nil {
+		return err
+	}
+
+	err = c.sendOne(ctx, accessToken, action, opts)
+	if err != nil {
+		return fmt.Errorf("rename tag %q to %q: %w", action.OldTag, action.NewTag, err)
+	}
+
+	return nil
+}
+
+// TagDelete removes tag from every item carrying it. This cannot be undone;
+// TagDeleteDryRun reports how many items would be affected.
+func (c *Client) TagDelete(ctx context.Context, accessToken, tag string, opts ...ActionOption) error {
+	action, err := newTagDeleteAction(tag)
+	if err != nil {
+		return err
+	}
+
+	err = c.sendOne(ctx, accessToken, action, opts)
+	if err != nil {
+		return fmt.Errorf("delete tag %q: %w", action.Tag, err)
+	}
+
+	return nil
+}
+
+// TagDeleteDryRun validates tag like TagDelete and returns the number of
+// items carrying it, without modifying anything.
+func (c *Client) TagDeleteDryRun(ctx context.Context, accessToken, tag string) (int, error) {
+	tag, err := validateTag("tag", tag)
+	if err != nil {
+		return 0, err
+	}
+
+	return c.Count(ctx, GetInput{
+		AccessToken: accessToken,
+		State:       StateAll,
+		Tag:         tag,
+	})
+}
+
+// TagMigrationReport describes what MigrateTags did.
+type TagMigrationReport struct {
+	// Items is the number of items moved off each old tag. An item
+	// carrying several tags merged together is counted once.
+	Items map[string]int
+	// Merged lists the new tags that several old tags were merged into.
+	Merged []string
+	// Failed holds the actions Pocket rejected.
+	Failed []Action
+}
+
+// MigrateTags applies renames, a map from old to new tag, across the
+// account. A plain rename is a single tag_rename action. Old tags merging
+// into the same new tag cannot be expressed that way, so every item carrying
+// one of them gets a tags_add of the new tag and a tags_remove of the old
+// ones instead; unlike rewriting the whole tag set, this keeps tags added to
+// the item in the meantime. A tag may not be both renamed and a rename
+// target. Rejected actions are listed in the report and make the returned
+// error non-nil.
+func (c *Client) MigrateTags(ctx context.Context, accessToken string, renames map[string]string, opts ...ActionOption) (*TagMigrationReport, error) {
+	sources := make(map[string][]string)
+	mapping := make(map[string]string, len(renames))
+	for oldTag, newTag := range renames {
+		action, err := newTagRenameAction(oldTag, newTag)
+		if err != nil {
+			return nil, err
+		}
+
+		if action.OldTag == action.NewTag {
+			continue
+		}
+
+		mapping[action.OldTag] = action.NewTag
+		sources[action.NewTag] = append(sources[action.NewTag], action.OldTag)
+	}
+
+	for newTag := range sources {
+		if _, ok := mapping[newTag]; ok {
+			return nil, &ValidationError{Field: "renames", Reason: fmt.Sprintf("%q is both renamed and a rename target", newTag)}
+		}
+	}
+
+	report := &TagMigrationReport{Items: make(map[string]int)}
+
+	merges := make(map[string]string)
+	for _, newTag := range slices.Sorted(maps.Keys(sources)) {
+		olds := sources[newTag]
+		if len(olds) > 1 {
+			report.Merged = append(report.Merged, newTag)
+			for _, oldTag := range olds {
+				merges[oldTag] = newTag
+			}
+			continue
+		}
+
+		if err := c.renameTag(ctx, accessToken, olds[0], newTag, report, opts); err != nil {
+			return report, err
+		}
+	}
+
+	for _, oldTag := range slices.Sorted(maps.Keys(merges)) {
+		if err := c.mergeTag(ctx, accessToken, oldTag, merges, report, opts); err != nil {
+			return report, err
+		}
+	}
+
+	if len(report.Failed) > 0 {
+		return report, fmt.Errorf("%d tag migration actions failed", len(report.Failed))
+	}
+
+	return report, nil
+}
+
+// renameTag counts the items carrying oldTag, which tag_rename does not
+// report, then renames it.
+func (c *Client) renameTag(ctx context.Context, accessToken, oldTag, newTag string, report *TagMigrationReport, opts []ActionOption) error {
+	n, err := c.Count(ctx, GetInput{AccessToken: accessToken, State: StateAll, Tag: oldTag})
+	if err != nil {
+		return err
+	}
+
+	action := Action{Action: ActionTagRename, OldTag: oldTag, NewTag: newTag}
+	err = c.sendOne(ctx, accessToken, action, opts)
+
+	var aerr *ActionError
+	switch {
+	case errors.As(err, &aerr):
+		report.Failed = append(report.Failed, action)
+	case err != nil:
+		return err
+	default:
+		report.Items[oldTag] = n
+	}
+
+	return nil
+}
+
+// mergeTag moves every item carrying oldTag to its new tag, applying all of
+// merges at once so an item with several merged tags is handled in one go.
+// Tags compare case-insensitively like sanitizeTags: an item already
+// carrying the new tag in any spelling gets no tags_add, and a merged tag
+// spelled like its new tag is kept rather than removed along with it.
+func (c *Client) mergeTag(ctx context.Context, accessToken, oldTag string, merges map[string]string, report *TagMigrationReport, opts []ActionOption) error {
+	filter := GetInput{
+		AccessToken: accessToken,
+		State:       StateAll,
+		Tag:         oldTag,
+		Detail:      DetailComplete,
+	}
+
+	targets := make(map[string]string, len(merges))
+	for old, newTag := range merges {
+		targets[strings.ToLower(old)] = newTag
+	}
+
+	n, err := c.bulkAction(ctx, filter, 0, opts, func(item Item) []Action {
+		carried := make(map[string]bool, len(item.Tags))
+		for _, tag := range item.Tags {
+			carried[strings.ToLower(tag)] = true
+		}
+
+		var adds, removes []string
+		for _, tag := range item.Tags {
+			newTag, ok := targets[strings.ToLower(tag)]
+			if !ok || strings.EqualFold(tag, newTag) {
+				continue
+			}
+
+			removes = append(removes, tag)
+			if key := strings.ToLower(newTag); !carried[key] {
+				carried[key] = true
+				adds = append(adds, newTag)
+			}
+		}
+
+		var actions []Action
+		if len(adds) > 0 {
+			actions = append(actions, Action{Action: ActionTagsAdd, ItemID: item.ItemID, Tags: adds})
+		}
+		if len(removes) > 0 {
+			actions = append(actions, Action{Action: ActionTagsRemove, ItemID: item.ItemID, Tags: removes})
+		}
+
+		return actions
+	})
+	report.Items[oldTag] = n
+
+	var berr *BatchError
+	switch {
+	case errors.As(err, &berr):
+		report.Failed = append(report.Failed, berr.Actions...)
+	case err != nil:
+		return err
+	}
+
+	return nil
+}
+
+// validateTag trims a single account-wide tag argument and rejects it when
+// empty or comma-separated.
+func validateTag(field, tag string) (string, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return "", &ValidationError{Field: field, Reason: "is empty"}
+	}
+
+	if strings.Contains(tag, ",") {
+		return "", &ValidationError{Field: field, Reason: fmt.Sprintf("%q must not contain commas", tag)}
+	}
+
+	return tag, nil
+}
+
+// sanitizeTags trims every tag, drops empty ones and removes case-insensitive
+// duplicates, keeping the first spelling. Pocket takes tags comma-joined, so
+// a tag containing a comma is rejected rather than silently split in two.
+func sanitizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	sanitized := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+
+		if strings.Contains(tag, ",") {
+			return nil, &ValidationError{Field: "Tags", Reason: fmt.Sprintf("%q must not contain commas", tag)}
+		}
+
+		key := strings.ToLower(tag)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		sanitized = append(sanitized, tag)
+	}
+
+	return sanitized, nil
+}
@@ -0,0 +1,379 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sanitizeTagsTests is shared by every entry point taking tags, so Add, add
+// actions and the tag methods are held to the same behavior.
+var sanitizeTagsTests = []struct {
+	name    string
+	tags    []string
+	want    []string
+	wantErr bool
+}{
+	{name: "Nil", tags: nil, want: []string{}},
+	{name: "Unchanged", tags: []string{"go", "read later"}, want: []string{"go", "read later"}},
+	{name: "Trimmed", tags: []string{" go", "rust\t"}, want: []string{"go", "rust"}},
+	{name: "Empty dropped", tags: []string{"", "  ", "go"}, want: []string{"go"}},
+	{name: "Case-insensitive dedupe keeps first", tags: []string{"Go", "go", " GO "}, want: []string{"Go"}},
+	{name: "Unicode", tags: []string{"чтение", "Чтение", "日本語", "café"}, want: []string{"чтение", "日本語", "café"}},
+	{name: "Comma", tags: []string{"go", "a,b"}, wantErr: true},
+}
+
+func TestSanitizeTags(t *testing.T) {
+	for _, tt := range sanitizeTagsTests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sanitizeTags(tt.tags)
+			if tt.wantErr {
+				var verr *ValidationError
+				if assert.ErrorAs(t, err, &verr) {
+					assert.Equal(t, "Tags", verr.Field)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSanitizeTags_EntryPoints(t *testing.T) {
+	// Each entry point sends the sanitized tags, comma-joined, or fails
+	// before sending anything.
+	entryPoints := []struct {
+		name    string
+		path    string
+		noTags  bool
+		call    func(c *Client, tags []string) error
+		tagsOut func(t *testing.T, body string) string
+	}{
+		{
+			name: "Add",
+			path: "/v3/add",
+			call: func(c *Client, tags []string) error {
+				_, err := c.Add(context.Background(), AddInput{URL: "https://example.com", Tags: tags, AccessToken: "access-to-ken"})
+				return err
+			},
+			tagsOut: func(t *testing.T, body string) string {
+				var req struct {
+					Tags string `json:"tags"`
+				}
+				assert.NoError(t, json.Unmarshal([]byte(body), &req))
+				return req.Tags
+			},
+		},
+		{
+			name: "AddBatchActions",
+			path: "/v3/send",
+			call: func(c *Client, tags []string) error {
+				_, err := c.AddBatchActions(context.Background(), "access-to-ken", []AddInput{{URL: "https://example.com", Tags: tags}})
+				return err
+			},
+			tagsOut: sentTags,
+		},
+		{
+			name:   "TagsAdd",
+			path:   "/v3/send",
+			noTags: true,
+			call: func(c *Client, tags []string) error {
+				return c.TagsAdd(context.Background(), "access-to-ken", "1", tags)
+			},
+			tagsOut: sentTags,
+		},
+		{
+			name: "Actions builder",
+			path: "/v3/send",
+			// The builder rejects an empty tag set like the methods do.
+			noTags: true,
+			call: func(c *Client, tags []string) error {
+				actions, err := NewActions().TagsReplace("1", tags...).Build()
+				if err != nil {
+					return err
+				}
+				_, err = c.Send(context.Background(), "access-to-ken", actions)
+				return err
+			},
+			tagsOut: sentTags,
+		},
+	}
+
+	const response = `{"status":1,"action_results":[true],"item":{"item_id":"1"}}`
+
+	for _, ep := range entryPoints {
+		for _, tt := range sanitizeTagsTests {
+			t.Run(ep.name+"/"+tt.name, func(t *testing.T) {
+				var body string
+				client := newClientWithCheck(t, http.StatusOK, ep.path, response, func(r *http.Request) {
+					body = readBody(t, r)
+				})
+
+				err := ep.call(client, tt.tags)
+				if tt.wantErr || (ep.noTags && len(tt.want) == 0) {
+					assert.Error(t, err)
+					assert.Empty(t, body)
+					return
+				}
+
+				assert.NoError(t, err)
+				assert.Equal(t, strings.Join(tt.want, ","), ep.tagsOut(t, body))
+			})
+		}
+	}
+}
+
+// sentTags returns the tags of the only action in a send request body.
+func sentTags(t *testing.T, body string) string {
+	var req struct {
+		Actions []sendAction `json:"actions"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(body), &req))
+	if !assert.Len(t, req.Actions, 1) {
+		return ""
+	}
+
+	return req.Actions[0].Tags
+}
+
+func TestClient_TagRename(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldTag   string
+		newTag   string
+		response string
+		wantBody string
+		wantErr  string
+		invalid  string
+	}{
+		{
+			name:     "Renamed",
+			oldTag:   "golang",
+			newTag:   "go",
+			response: `{"status":1,"action_results":[true]}`,
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"tag_rename","old_tag":"golang","new_tag":"go"}]}`,
+		},
+		{
+			name:     "Trimmed",
+			oldTag:   " Golang ",
+			newTag:   "go\t",
+			response: `{"status":1,"action_results":[true]}`,
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"tag_rename","old_tag":"Golang","new_tag":"go"}]}`,
+		},
+		{
+			name:     "Rejected",
+			oldTag:   "golang",
+			newTag:   "go",
+			response: `{"status":1,"action_results":[false]}`,
+			wantBody: `{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"tag_rename","old_tag":"golang","new_tag":"go"}]}`,
+			wantErr:  `rename tag "golang" to "go": tag_rename failed: 1 actions failed, first tag_rename at index 0`,
+		},
+		{name: "Empty old tag", oldTag: " ", newTag: "go", invalid: "oldTag"},
+		{name: "Empty new tag", oldTag: "golang", invalid: "newTag"},
+		{name: "Comma in old tag", oldTag: "go,lang", newTag: "go", invalid: "oldTag"},
+		{name: "Comma in new tag", oldTag: "golang", newTag: "go,", invalid: "newTag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var responses []string
+			if tt.response != "" {
+				responses = append(responses, tt.response)
+			}
+			client, bodies := newScriptedClient(t, "/v3/send", responses...)
+
+			err := client.TagRename(context.Background(), "access-to-ken", tt.oldTag, tt.newTag)
+
+			if tt.invalid != "" {
+				var verr *ValidationError
+				if assert.ErrorAs(t, err, &verr) {
+					assert.Equal(t, tt.invalid, verr.Field)
+				}
+				assert.Empty(t, *bodies)
+				return
+			}
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+
+				var aerr *ActionError
+				assert.ErrorAs(t, err, &aerr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if assert.Len(t, *bodies, 1) {
+				assert.JSONEq(t, tt.wantBody, (*bodies)[0])
+			}
+		})
+	}
+}
+
+func TestClient_TagDelete(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/send",
+		`{"status":1,"action_results":[true]}`,
+		`{"status":1,"action_results":[false]}`,
+	)
+
+	assert.NoError(t, client.TagDelete(context.Background(), "access-to-ken", " obsolete "))
+	assert.EqualError(t, client.TagDelete(context.Background(), "access-to-ken", "missing"), `delete tag "missing": tag_delete failed: 1 actions failed, first tag_delete at index 0`)
+
+	if assert.Len(t, *bodies, 2) {
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"tag_delete","tag":"obsolete"}]}`, (*bodies)[0])
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","actions":[{"action":"tag_delete","tag":"missing"}]}`, (*bodies)[1])
+	}
+
+	var verr *ValidationError
+	assert.ErrorAs(t, client.TagDelete(context.Background(), "access-to-ken", "a,b"), &verr)
+	assert.Len(t, *bodies, 2)
+}
+
+func TestClient_TagDeleteDryRun(t *testing.T) {
+	client, bodies := newScriptedClient(t, "/v3/get", `{"status":1,"list":{"1":{"item_id":"1"}},"since":1471870000,"total":"42"}`)
+
+	got, err := client.TagDeleteDryRun(context.Background(), "access-to-ken", "obsolete")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, got)
+
+	if assert.Len(t, *bodies, 1) {
+		assert.JSONEq(t, `{"consumer_key":"key","access_token":"access-to-ken","count":1,"state":"all","tag":"obsolete","detailType":"simple","total":"1"}`, (*bodies)[0])
+	}
+
+	_, err = client.TagDeleteDryRun(context.Background(), "access-to-ken", "")
+	var verr *ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Len(t, *bodies, 1)
+}
+
+func TestClient_MigrateTags(t *testing.T) {
+	account := newFakeAccount(90)
+	for i := range account.items {
+		switch i % 6 {
+		case 0:
+			account.items[i].Tags = []string{"golang"}
+		case 1:
+			account.items[i].Tags = []string{"Golang", "web"}
+		case 2:
+			account.items[i].Tags = []string{"golang", "Golang"}
+		case 3:
+			account.items[i].Tags = []string{"js"}
+		case 4:
+			account.items[i].Tags = []string{"web"}
+		}
+	}
+
+	report, err := account.client(t).MigrateTags(context.Background(), "access-to-ken", map[string]string{
+		"golang": "go",
+		"Golang": "go",
+		"js":     "javascript",
+		"web":    "web",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &TagMigrationReport{
+		Items:  map[string]int{"Golang": 30, "golang": 15, "js": 15},
+		Merged: []string{"go"},
+	}, report)
+
+	counts := make(map[string]int)
+	for _, item := range account.items {
+		assert.NotContains(t, item.Tags, "golang")
+		assert.NotContains(t, item.Tags, "Golang")
+		for _, tag := range item.Tags {
+			counts[tag]++
+		}
+	}
+	assert.Equal(t, map[string]int{"go": 45, "javascript": 15, "web": 30}, counts)
+}
+
+func TestClient_MigrateTags_Failures(t *testing.T) {
+	account := newFakeAccount(40)
+	for i := range account.items {
+		account.items[i].Tags = []string{[]string{"a", "b", "c"}[i%3]}
+	}
+	account.reject = func(id string) bool { return id == "c" || id == "4" }
+
+	report, err := account.client(t).MigrateTags(context.Background(), "access-to-ken", map[string]string{
+		"a": "ab",
+		"b": "ab",
+		"c": "see",
+	})
+	assert.EqualError(t, err, "3 tag migration actions failed")
+	assert.Equal(t, map[string]int{"a": 13, "b": 13}, report.Items)
+	assert.Equal(t, []Action{
+		{Action: ActionTagRename, OldTag: "c", NewTag: "see"},
+		{Action: ActionTagsAdd, ItemID: "4", Tags: []string{"ab"}},
+		{Action: ActionTagsRemove, ItemID: "4", Tags: []string{"a"}},
+	}, report.Failed)
+}
+
+func TestClient_MigrateTags_KeepsConcurrentTags(t *testing.T) {
+	account := newFakeAccount(40)
+	for i := range account.items {
+		account.items[i].Tags = []string{[]string{"a", "b"}[i%2]}
+	}
+
+	// Another client tags every item after the merge retrieved it.
+	account.onSend = func() {
+		for i := range account.items {
+			if !slices.Contains(account.items[i].Tags, "later") {
+				account.items[i].Tags = append(account.items[i].Tags, "later")
+			}
+		}
+	}
+
+	_, err := account.client(t).MigrateTags(context.Background(), "access-to-ken", map[string]string{"a": "ab", "b": "ab"})
+	assert.NoError(t, err)
+	for _, item := range account.items {
+		assert.ElementsMatch(t, []string{"ab", "later"}, item.Tags, item.ItemID)
+	}
+}
+
+func TestClient_MigrateTags_CaseOnlyMerge(t *testing.T) {
+	account := newFakeAccount(3)
+	account.items[0].Tags = []string{"Go"}
+	account.items[1].Tags = []string{"golang"}
+	account.items[2].Tags = []string{"golang", "GO"}
+
+	report, err := account.client(t).MigrateTags(context.Background(), "access-to-ken", map[string]string{
+		"Go":     "go",
+		"golang": "go",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"Go": 0, "golang": 2}, report.Items)
+
+	// Item 1 already carries go and item 3 only loses golang: no empty
+	// actions and no removal of the tag being merged into.
+	assert.Equal(t, []string{"2", "2", "3"}, account.actioned)
+	assert.Equal(t, []string{"Go"}, account.items[0].Tags)
+	assert.Equal(t, []string{"go"}, account.items[1].Tags)
+	assert.Equal(t, []string{"GO"}, account.items[2].Tags)
+}
+
+func TestClient_MigrateTags_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		renames map[string]string
+	}{
+		{name: "Empty new tag", renames: map[string]string{"a": ""}},
+		{name: "Comma", renames: map[string]string{"a": "b,c"}},
+		{name: "Chained", renames: map[string]string{"a": "b", "b": "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := newFakeAccount(1)
+
+			_, err := account.client(t).MigrateTags(context.Background(), "access-to-ken", tt.renames)
+			var verr *ValidationError
+			assert.ErrorAs(t, err, &verr)
+			assert.Zero(t, account.gets+account.sends)
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package pocket
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// ErrTokenNotFound is returned by a TokenStore that holds no token for a
+// user.
+var ErrTokenNotFound = errors.New("token not found")
+
+// ErrWrongPassphrase is returned by EncryptedFileStore when the file cannot
+// be decrypted with its passphrase. A file modified by someone without the
+// passphrase, its key parameters included, fails the same way.
+var ErrWrongPassphrase = errors.New("wrong passphrase")
+
+// TokenStore keeps access tokens between runs, keyed by user, typically
+// the Username of an AuthorizeResponse.
+type TokenStore interface {
+	// Token returns the token of user, or ErrTokenNotFound.
+	Token(user string) (string, error)
+	// SetToken stores token for user, replacing any previous one.
+	SetToken(user, token string) error
+	// DeleteToken removes the token of user. Removing a missing token is
+	// not an error.
+	DeleteToken(user string) error
+}
+
+const (
+	tokenFileVersion = 1
+	tokenFileKDF     = "argon2id"
+	saltSize         = 16
+)
+
+// kdfParams are the argon2id costs of a token file; Memory is in KiB.
+type kdfParams struct {
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+}
+
+var (
+	// defaultKDFParams is the first recommendation of RFC 9106.
+	defaultKDFParams = kdfParams{Time: 3, Memory: 64 * 1024, Threads: 4}
+	// A file asking for less than minKDFParams or more than maxKDFParams is
+	// corrupt or tampered with: too little would weaken the key, too much
+	// would stall or exhaust the machine on every read.
+	minKDFParams = kdfParams{Time: 1, Memory: 19 * 1024, Threads: 1}
+	maxKDFParams = kdfParams{Time: 16, Memory: 1024 * 1024, Threads: 64}
+)
+
+func (p kdfParams) within(lo, hi kdfParams) bool {
+	return p.Time >= lo.Time && p.Time <= hi.Time &&
+		p.Memory >= lo.Memory && p.Memory <= hi.Memory &&
+		p.Threads >= lo.Threads && p.Threads <= hi.Threads
+}
+
+// tokenHeader describes how the key of a token file is derived. Its JSON
+// encoding is sealed along with the data as additional data, so no field can
+// be changed without the passphrase.
+type tokenHeader struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	kdfParams
+	Salt []byte `json:"salt"`
+}
+
+// tokenFile is the on-disk form of an EncryptedFileStore. Data is the
+// AES-256-GCM sealed JSON object of tokens by user.
+type tokenFile struct {
+	tokenHeader
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// EncryptedFileStore is a TokenStore keeping all tokens in one file,
+// encrypted with AES-256-GCM under a key derived from a passphrase with
+// argon2id. Every write uses a fresh salt and nonce and replaces the
+// file atomically, readable by the owner only.
+//
+// It is safe for concurrent use within one process; separate processes
+// sharing the file may overwrite each other's changes.
+type EncryptedFileStore struct {
+	path string
+
+	mu         sync.Mutex
+	passphrase string
+	params     kdfParams
+}
+
+// NewEncryptedFileStore returns a store for the file at path, which is
+// created by the first SetToken.
+func NewEncryptedFileStore(path, passphrase string) (*EncryptedFileStore, error) {
+	if path == "" {
+		return nil, &ValidationError{Field: "path", Reason: "is empty"}
+	}
+	if passphrase == "" {
+		return nil, &ValidationError{Field: "passphrase", Reason: "is empty"}
+	}
+
+	return &EncryptedFileStore{path: path, passphrase: passphrase, params: defaultKDFParams}, nil
+}
+
+func (s *EncryptedFileStore) Token(user string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.load(s.passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	token, ok := tokens[user]
+	if !ok {
+		return "", ErrTokenNotFound
+	}
+
+	return token, nil
+}
+
+func (s *EncryptedFileStore) SetToken(user, token string) error {
+	if token == "" {
+		return &ValidationError{Field: "token", Reason: "is empty"}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.load(s.passphrase)
+	if err != nil {
+		return err
+	}
+
+	tokens[user] = token
+
+	return s.save(s.passphrase, tokens)
+}
+
+func (s *EncryptedFileStore) DeleteToken(user string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.load(s.passphrase)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := tokens[user]; !ok {
+		return nil
+	}
+	delete(tokens, user)
+
+	return s.save(s.passphrase, tokens)
+}
+
+// Rekey re-encrypts the file under newPassphrase and makes the store use
+// it. It returns ErrWrongPassphrase, leaving the file untouched, when
+// oldPassphrase does not decrypt it.
+func (s *EncryptedFileStore) Rekey(oldPassphrase, newPassphrase string) error {
+	if newPassphrase == "" {
+		return &ValidationError{Field: "newPassphrase", Reason: "is empty"}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens, err := s.load(oldPassphrase)
+	if err != nil {
+		return err
+	}
+
+	if err := s.save(newPassphrase, tokens); err != nil {
+		return err
+	}
+	s.passphrase = newPassphrase
+
+	return nil
+}
+
+// load decrypts the file with passphrase. A missing file holds no tokens.
+func (s *EncryptedFileStore) load(passphrase string) (map[string]string, error) {
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read token file: %w", err)
+	}
+
+	var file tokenFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("decode token file: %w", err)
+	}
+	if file.Version != tokenFileVersion {
+		return nil, fmt.Errorf("unsupported token file version %d", file.Version)
+	}
+	if file.KDF != tokenFileKDF {
+		return nil, fmt.Errorf("unsupported token file key derivation %q", file.KDF)
+	}
+
+	if !file.kdfParams.within(minKDFParams, maxKDFParams) {
+		return nil, fmt.Errorf("token file asks for key derivation costs %+v, want between %+v and %+v", file.kdfParams, minKDFParams, maxKDFParams)
+	}
+
+	aead, ad, err := newTokenCipher(passphrase, file.tokenHeader)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("token file nonce is %d bytes long, want %d", len(file.Nonce), aead.NonceSize())
+	}
+
+	plain, err := aead.Open(nil, file.Nonce, file.Data, ad)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	tokens := map[string]string{}
+	if err := json.Unmarshal(plain, &tokens); err != nil {
+		return nil, fmt.Errorf("decode tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// save encrypts tokens with passphrase and replaces the file.
+func (s *EncryptedFileStore) save(passphrase string, tokens map[string]string) error {
+	plain, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+
+	file := tokenFile{tokenHeader: tokenHeader{
+		Version:   tokenFileVersion,
+		KDF:       tokenFileKDF,
+		kdfParams: s.params,
+		Salt:      make([]byte, saltSize),
+	}}
+	if _, err := rand.Read(file.Salt); err != nil {
+		return err
+	}
+
+	aead, ad, err := newTokenCipher(passphrase, file.tokenHeader)
+	if err != nil {
+		return err
+	}
+
+	file.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return err
+	}
+	file.Data = aead.Seal(nil, file.Nonce, plain, ad)
+
+	raw, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write token file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write token file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write token file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("write token file: %w", err)
+	}
+
+	return nil
+}
+
+// newTokenCipher derives the key described by header from passphrase and
+// returns the cipher together with the additional data binding header to
+// the sealed tokens.
+func newTokenCipher(passphrase string, header tokenHeader) (cipher.AEAD, []byte, error) {
+	if len(header.Salt) == 0 {
+		return nil, nil, errors.New("token file has no key parameters")
+	}
+
+	ad, err := json.Marshal(header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := argon2.IDKey([]byte(passphrase), header.Salt, header.Time, header.Memory, header.Threads, 32)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return aead, ad, nil
+}
@@ -0,0 +1,179 @@
+package pocket
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFileStore(t *testing.T, path, passphrase string) *EncryptedFileStore {
+	store, err := NewEncryptedFileStore(path, passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Keeps the tests fast; files record their own key derivation costs.
+	store.params = minKDFParams
+
+	return store
+}
+
+func TestEncryptedFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	store := newTestFileStore(t, path, "correct horse")
+
+	_, err := store.Token("alice")
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+
+	assert.NoError(t, store.SetToken("alice", "token-a"))
+	assert.NoError(t, store.SetToken("bob", "token-b"))
+	assert.NoError(t, store.SetToken("alice", "token-a2"))
+
+	raw, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(raw), "token-a")
+	assert.NotContains(t, string(raw), "alice")
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// A new store with the same passphrase reads what the first one wrote.
+	reopened := newTestFileStore(t, path, "correct horse")
+	token, err := reopened.Token("alice")
+	assert.NoError(t, err)
+	assert.Equal(t, "token-a2", token)
+
+	assert.NoError(t, reopened.DeleteToken("bob"))
+	assert.NoError(t, reopened.DeleteToken("bob"))
+	_, err = store.Token("bob")
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+}
+
+func TestEncryptedFileStore_WrongPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	assert.NoError(t, newTestFileStore(t, path, "correct horse").SetToken("alice", "token-a"))
+
+	wrong := newTestFileStore(t, path, "battery staple")
+	_, err := wrong.Token("alice")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+	assert.ErrorIs(t, wrong.SetToken("bob", "token-b"), ErrWrongPassphrase)
+}
+
+func TestEncryptedFileStore_Tampered(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	store := newTestFileStore(t, path, "correct horse")
+	assert.NoError(t, store.SetToken("alice", "token-a"))
+
+	raw, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	// Change the first character of the base64 ciphertext.
+	i := strings.Index(string(raw), `"data":"`) + len(`"data":"`)
+	if raw[i] == 'A' {
+		raw[i] = 'B'
+	} else {
+		raw[i] = 'A'
+	}
+	assert.NoError(t, os.WriteFile(path, raw, 0o600))
+
+	_, err = store.Token("alice")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+}
+
+func TestEncryptedFileStore_Rekey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	store := newTestFileStore(t, path, "old")
+	assert.NoError(t, store.SetToken("alice", "token-a"))
+
+	before, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, store.Rekey("not old", "new"), ErrWrongPassphrase)
+	after, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	assert.NoError(t, store.Rekey("old", "new"))
+
+	token, err := store.Token("alice")
+	assert.NoError(t, err)
+	assert.Equal(t, "token-a", token)
+
+	_, err = newTestFileStore(t, path, "old").Token("alice")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+
+	token, err = newTestFileStore(t, path, "new").Token("alice")
+	assert.NoError(t, err)
+	assert.Equal(t, "token-a", token)
+}
+
+func TestNewEncryptedFileStore_Validation(t *testing.T) {
+	_, err := NewEncryptedFileStore("", "pass")
+	assert.Error(t, err)
+
+	_, err = NewEncryptedFileStore("tokens.json", "")
+	assert.Error(t, err)
+}
+
+// rewriteTokenFile replaces old with new in the token file at path.
+func rewriteTokenFile(t *testing.T, path, old, new string) {
+	raw, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(raw), old)
+	assert.NoError(t, os.WriteFile(path, []byte(strings.Replace(string(raw), old, new, 1)), 0o600))
+}
+
+func TestEncryptedFileStore_KDFParams(t *testing.T) {
+	minTime := fmt.Sprintf(`"time":%d`, minKDFParams.Time)
+	minMemory := fmt.Sprintf(`"memory":%d`, minKDFParams.Memory)
+	minThreads := fmt.Sprintf(`"threads":%d`, minKDFParams.Threads)
+
+	for _, tc := range [][2]string{
+		{minTime, `"time":0`},
+		{minTime, `"time":17`},
+		{minMemory, `"memory":1024`},
+		{minMemory, `"memory":4294967295`},
+		{minThreads, `"threads":0`},
+		{minThreads, `"threads":255`},
+	} {
+		path := filepath.Join(t.TempDir(), "tokens.json")
+		store := newTestFileStore(t, path, "correct horse")
+		assert.NoError(t, store.SetToken("alice", "token-a"))
+
+		rewriteTokenFile(t, path, tc[0], tc[1])
+
+		_, err := store.Token("alice")
+		assert.ErrorContains(t, err, "key derivation costs", tc[1])
+		assert.NotErrorIs(t, err, ErrWrongPassphrase)
+	}
+}
+
+func TestEncryptedFileStore_TamperedHeader(t *testing.T) {
+	for _, tc := range [][2]string{
+		{`"time":1`, `"time":2`},
+		{`"threads":1`, `"threads":2`},
+	} {
+		path := filepath.Join(t.TempDir(), "tokens.json")
+		store := newTestFileStore(t, path, "correct horse")
+		assert.NoError(t, store.SetToken("alice", "token-a"))
+
+		rewriteTokenFile(t, path, tc[0], tc[1])
+
+		_, err := store.Token("alice")
+		assert.ErrorIs(t, err, ErrWrongPassphrase, tc[1])
+	}
+
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	store := newTestFileStore(t, path, "correct horse")
+	assert.NoError(t, store.SetToken("alice", "token-a"))
+
+	rewriteTokenFile(t, path, `"kdf":"argon2id"`, `"kdf":"pbkdf2"`)
+
+	_, err := store.Token("alice")
+	assert.ErrorContains(t, err, "key derivation")
+	assert.NotErrorIs(t, err, ErrWrongPassphrase)
+}
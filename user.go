@@ -0,0 +1,167 @@
+package pocket
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// UserClient acts for the single user of an access token, so its methods
+// take no token. Token fields of the inputs it is given, and
+// WithAccessToken options, are ignored.
+type UserClient struct {
+	client      *Client
+	accessToken string
+}
+
+// ForUser returns a UserClient for accessToken. It shares c's HTTP client
+// and transport and is cheap enough for servers to make one per request.
+// It never falls back to the token set with WithAccessToken, so a missing
+// user token cannot act on another account: with an empty accessToken every
+// call fails with a *ValidationError. Any number of UserClients of one
+// Client may be used concurrently.
+func (c *Client) ForUser(accessToken string) *UserClient {
+	u := &UserClient{client: c, accessToken: accessToken}
+	if c.accessToken != "" {
+		base := *c
+		base.accessToken = ""
+		u.client = &base
+	}
+
+	return u
+}
+
+// AccessToken returns the token u acts with.
+func (u *UserClient) AccessToken() string {
+	return u.accessToken
+}
+
+// ValidateToken is Client.ValidateToken for u's token.
+func (u *UserClient) ValidateToken(ctx context.Context) error {
+	return u.client.ValidateToken(ctx, u.accessToken)
+}
+
+// Add is Client.Add for u's user.
+func (u *UserClient) Add(ctx context.Context, input AddInput, opts ...AddOption) (*AddedItem, error) {
+	return u.client.Add(ctx, u.addInput(input, opts))
+}
+
+// AddURL is Client.AddURL for u's user.
+func (u *UserClient) AddURL(ctx context.Context, rawurl string, opts ...AddOption) (*AddedItem, error) {
+	return u.Add(ctx, AddInput{URL: rawurl}, opts...)
+}
+
+// Save is Client.Save for u's user.
+func (u *UserClient) Save(ctx context.Context, input AddInput, opts ...AddOption) (*SaveResult, error) {
+	return u.client.Save(ctx, u.addInput(input, opts))
+}
+
+// addInput applies opts to input before setting u's token, so that
+// WithAccessToken cannot switch accounts.
+func (u *UserClient) addInput(input AddInput, opts []AddOption) AddInput {
+	for _, opt := range opts {
+		opt(&input)
+	}
+	input.AccessToken = u.accessToken
+
+	return input
+}
+
+// Get is Client.Get for u's user.
+func (u *UserClient) Get(ctx context.Context, input GetInput) (*GetResponse, error) {
+	input.AccessToken = u.accessToken
+	return u.client.Get(ctx, input)
+}
+
+// GetAll is Client.GetAll for u's user.
+func (u *UserClient) GetAll(ctx context.Context, input GetInput) ([]Item, error) {
+	input.AccessToken = u.accessToken
+	return u.client.GetAll(ctx, input)
+}
+
+// Items is Client.Items for u's user.
+func (u *UserClient) Items(ctx context.Context, input GetInput) iter.Seq2[Item, error] {
+	input.AccessToken = u.accessToken
+	return u.client.Items(ctx, input)
+}
+
+// Count is Client.Count for u's user.
+func (u *UserClient) Count(ctx context.Context, input GetInput) (int, error) {
+	input.AccessToken = u.accessToken
+	return u.client.Count(ctx, input)
+}
+
+// GetItemByURL is Client.GetItemByURL for u's user.
+func (u *UserClient) GetItemByURL(ctx context.Context, rawurl string) (*Item, error) {
+	return u.client.GetItemByURL(ctx, u.accessToken, rawurl)
+}
+
+// Sync is Client.Sync for u's user.
+func (u *UserClient) Sync(ctx context.Context, since time.Time) (*SyncResult, error) {
+	return u.client.Sync(ctx, u.accessToken, since)
+}
+
+// ListTags is Client.ListTags for u's user.
+func (u *UserClient) ListTags(ctx context.Context) ([]TagCount, error) {
+	return u.client.ListTags(ctx, u.accessToken)
+}
+
+// Stats is Client.Stats for u's user.
+func (u *UserClient) Stats(ctx context.Context) (*AccountStats, error) {
+	return u.client.Stats(ctx, u.accessToken)
+}
+
+// Send is Client.Send for u's user.
+func (u *UserClient) Send(ctx context.Context, actions []Action, opts ...ActionOption) (*SendResponse, error) {
+	return u.client.Send(ctx, u.accessToken, actions, opts...)
+}
+
+// Archive is Client.Archive for u's user.
+func (u *UserClient) Archive(ctx context.Context, itemID ItemID, opts ...ActionOption) error {
+	return u.client.Archive(ctx, u.accessToken, itemID, opts...)
+}
+
+// Readd is Client.Readd for u's user.
+func (u *UserClient) Readd(ctx context.Context, itemID ItemID, opts ...ActionOption) error {
+	return u.client.Readd(ctx, u.accessToken, itemID, opts...)
+}
+
+// Favorite is Client.Favorite for u's user.
+func (u *UserClient) Favorite(ctx context.Context, itemID ItemID, opts ...ActionOption) error {
+	return u.client.Favorite(ctx, u.accessToken, itemID, opts...)
+}
+
+// Unfavorite is Client.Unfavorite for u's user.
+func (u *UserClient) Unfavorite(ctx context.Context, itemID ItemID, opts ...ActionOption) error {
+	return u.client.Unfavorite(ctx, u.accessToken, itemID, opts...)
+}
+
+// Delete is Client.Delete for u's user.
+func (u *UserClient) Delete(ctx context.Context, itemID ItemID, opts ...ActionOption) error {
+	return u.client.Delete(ctx, u.accessToken, itemID, opts...)
+}
+
+// TagsAdd is Client.TagsAdd for u's user.
+func (u *UserClient) TagsAdd(ctx context.Context, itemID ItemID, tags []string, opts ...ActionOption) error {
+	return u.client.TagsAdd(ctx, u.accessToken, itemID, tags, opts...)
+}
+
+// TagsRemove is Client.TagsRemove for u's user.
+func (u *UserClient) TagsRemove(ctx context.Context, itemID ItemID, tags []string, opts ...ActionOption) error {
+	return u.client.TagsRemove(ctx, u.accessToken, itemID, tags, opts...)
+}
+
+// TagsReplace is Client.TagsReplace for u's user.
+func (u *UserClient) TagsReplace(ctx context.Context, itemID ItemID, tags []string, opts ...ActionOption) error {
+	return u.client.TagsReplace(ctx, u.accessToken, itemID, tags, opts...)
+}
+
+// TagsClear is Client.TagsClear for u's user.
+func (u *UserClient) TagsClear(ctx context.Context, itemID ItemID, opts ...ActionOption) error {
+	return u.client.TagsClear(ctx, u.accessToken, itemID, opts...)
+}
+
+// ArchiveByURL is Client.ArchiveByURL for u's user.
+func (u *UserClient) ArchiveByURL(ctx context.Context, rawurl string, opts ...ActionOption) error {
+	return u.client.ArchiveByURL(ctx, u.accessToken, rawurl, opts...)
+}
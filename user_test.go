@@ -0,0 +1,126 @@
+package pocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newEchoClient answers every request with data derived from its access
+// token, so a response reaching the wrong user is detectable.
+func newEchoClient(t *testing.T) *Client {
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		var body struct {
+			AccessToken string `json:"access_token"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		var resp string
+		switch r.URL.Path {
+		case "/v3/add":
+			resp = fmt.Sprintf(`{"item":{"item_id":%q},"status":1}`, body.AccessToken)
+		case "/v3/get":
+			resp = fmt.Sprintf(`{"status":1,"list":{"1":{"item_id":"1","given_url":"https://example.com/%s"}}}`, body.AccessToken)
+		case "/v3/send":
+			resp = `{"status":1,"action_results":[true]}`
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(resp))}, nil
+	})
+
+	return &Client{client: &http.Client{Transport: transport}, consumerKey: "key"}
+}
+
+func TestUserClient(t *testing.T) {
+	user := newEchoClient(t).ForUser("alice")
+	ctx := context.Background()
+
+	assert.Equal(t, "alice", user.AccessToken())
+
+	added, err := user.Add(ctx, AddInput{URL: "https://example.com/", AccessToken: "mallory"})
+	assert.NoError(t, err)
+	assert.Equal(t, ItemID("alice"), added.ItemID)
+
+	resp, err := user.Get(ctx, GetInput{})
+	if assert.NoError(t, err) && assert.Len(t, resp.Items, 1) {
+		assert.Equal(t, "https://example.com/alice", resp.Items[0].GivenURL)
+	}
+
+	assert.NoError(t, user.Archive(ctx, "1"))
+}
+
+// Many users share one parent concurrently; meant to run under -race.
+func TestUserClient_Concurrent(t *testing.T) {
+	parent := newEchoClient(t)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			token := fmt.Sprintf("user-%d", i)
+			user := parent.ForUser(token)
+
+			for range 10 {
+				added, err := user.AddURL(ctx, "https://example.com/")
+				if assert.NoError(t, err) {
+					assert.Equal(t, ItemID(token), added.ItemID)
+				}
+
+				item, err := user.GetItemByURL(ctx, "https://example.com/"+token)
+				if assert.NoError(t, err) {
+					assert.Equal(t, "https://example.com/"+token, item.GivenURL)
+				}
+
+				assert.NoError(t, user.Favorite(ctx, "1"))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestClient_ForUser_EmptyToken(t *testing.T) {
+	user := newEchoClient(t).WithAccessToken("owner").ForUser("")
+	ctx := context.Background()
+
+	var verr *ValidationError
+	_, err := user.AddURL(ctx, "https://example.com/")
+	assert.ErrorAs(t, err, &verr)
+
+	_, err = user.Get(ctx, GetInput{})
+	assert.ErrorAs(t, err, &verr)
+
+	assert.ErrorAs(t, user.Archive(ctx, "1"), &verr)
+}
+
+func TestUserClient_WithAccessToken(t *testing.T) {
+	parent := newEchoClient(t).WithAccessToken("owner")
+	ctx := context.Background()
+
+	added, err := parent.ForUser("alice").AddURL(ctx, "https://example.com/", WithAccessToken("mallory"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, ItemID("alice"), added.ItemID)
+	}
+
+	// Blanking the token does not reach the parent's either.
+	_, err = parent.ForUser("alice").AddURL(ctx, "https://example.com/", WithAccessToken(""))
+	assert.NoError(t, err)
+
+	saved, err := parent.ForUser("alice").Save(ctx, AddInput{URL: "https://example.com/alice"}, WithAccessToken("mallory"))
+	if assert.NoError(t, err) {
+		if assert.NotNil(t, saved.Existing) {
+			assert.Equal(t, "https://example.com/alice", saved.Existing.GivenURL)
+		}
+	}
+}